@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// agingBucket is a range of days past due; Max 0 means unbounded.
+type agingBucket struct {
+	Label    string
+	Min, Max int
+}
+
+// agingBuckets are the overdue ranges reported by `aging`, in display order.
+var agingBuckets = []agingBucket{
+	{"1-30", 1, 30},
+	{"31-60", 31, 60},
+	{"61-90", 61, 90},
+	{"90+", 91, 0},
+}
+
+// neverContactedBucket holds overdue contacts with no last_contacted date.
+const neverContactedBucket = "never"
+
+func agingCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "aging",
+		Usage:       "apeople aging",
+		Description: "Bucket overdue contacts by how many days past due they are",
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+
+			buckets := map[string][]neglectedContact{neverContactedBucket: {}}
+			for _, b := range agingBuckets {
+				buckets[b.Label] = []neglectedContact{}
+			}
+			for _, row := range overdueContacts(contacts) {
+				if row.DaysOverdue == nil {
+					buckets[neverContactedBucket] = append(buckets[neverContactedBucket], row)
+					continue
+				}
+				for _, b := range agingBuckets {
+					if *row.DaysOverdue >= b.Min && (b.Max == 0 || *row.DaysOverdue <= b.Max) {
+						buckets[b.Label] = append(buckets[b.Label], row)
+						break
+					}
+				}
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(buckets)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			labels := []string{}
+			for _, b := range agingBuckets {
+				labels = append(labels, b.Label)
+			}
+			labels = append(labels, neverContactedBucket)
+
+			fmt.Println("# Overdue contacts by days past due")
+			for _, label := range labels {
+				rows := buckets[label]
+				heading := label + " days"
+				if label == neverContactedBucket {
+					heading = "never contacted"
+				}
+				fmt.Printf("\n## %s (%d)\n", heading, len(rows))
+				for _, r := range rows {
+					if r.DaysOverdue == nil {
+						fmt.Printf("  #%-4d %s\n", r.IndexID, r.Title)
+					} else {
+						fmt.Printf("  #%-4d %s (+%d)\n", r.IndexID, r.Title, *r.DaysOverdue)
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+var (
+	fakeGivenNames = []string{"Alex", "Blake", "Casey", "Drew", "Emery", "Finley", "Gray", "Harper",
+		"Indigo", "Jordan", "Kai", "Logan", "Morgan", "Noel", "Oakley", "Parker",
+		"Quinn", "Riley", "Sage", "Taylor", "Umber", "Val", "Wren", "Yael"}
+	fakeFamilyNames = []string{"Abbott", "Brooks", "Carver", "Dalton", "Ellis", "Foster", "Garland", "Hale",
+		"Irving", "Jensen", "Keller", "Lowry", "Mercer", "Nash", "Oakes", "Pryor",
+		"Quill", "Rowe", "Sutton", "Tate", "Upton", "Vance", "Whitley", "Yates"}
+)
+
+// fakeHash derives a stable number from a value, so the same real value always
+// maps to the same fake one. kind keeps e.g. a company and a person with the
+// same name apart.
+func fakeHash(kind, value string) uint64 {
+	sum := sha256.Sum256([]byte(kind + "\x00" + strings.ToLower(strings.TrimSpace(value))))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
+// fakeName returns a made-up "Given Family" name for a real one. Different
+// real names can share a fake; IDs still tell the contacts apart.
+func fakeName(real string) string {
+	if real == "" {
+		return ""
+	}
+	h := fakeHash("name", real)
+	given := fakeGivenNames[h%uint64(len(fakeGivenNames))]
+	family := fakeFamilyNames[(h/uint64(len(fakeGivenNames)))%uint64(len(fakeFamilyNames))]
+	return given + " " + family
+}
+
+// fakeToken returns a short stable token for a value, or "" for "".
+func fakeToken(kind, real string) string {
+	if real == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%06x", kind, fakeHash(kind, real)%0xffffff)
+}
+
+// fakePhone returns a fictional 555 number for a real one.
+func fakePhone(real string) string {
+	if real == "" {
+		return ""
+	}
+	h := fakeHash("phone", real)
+	return fmt.Sprintf("+1-555-%03d-%04d", h%1000, (h>>16)%10000)
+}
+
+// fakeURL keeps the scheme-and-host shape of a link while hiding its target.
+func fakeURL(kind, real string) string {
+	if real == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://example.com/%s", fakeToken(kind, real))
+}
+
+// anonymizeContact replaces personal details with deterministic fakes. IDs,
+// dates, states, types, tags and relations are kept so the export has the same
+// shape as the original; free text such as notes is replaced wholesale.
+func anonymizeContact(c model.Contact) model.Contact {
+	c.Title = fakeName(c.Title)
+	aliases := make([]string, 0, len(c.Aliases))
+	for _, a := range c.Aliases {
+		aliases = append(aliases, fakeToken("alias", a))
+	}
+	if len(aliases) > 0 {
+		c.Aliases = aliases
+	}
+	if c.Email != "" {
+		c.Email = fakeToken("user", c.Email) + "@example.com"
+	}
+	if c.Phone != "" {
+		c.Phone = fakePhone(c.Phone)
+	}
+	emails := make([]model.LabeledValue, 0, len(c.Emails))
+	for _, e := range c.Emails {
+		emails = append(emails, model.LabeledValue{Label: e.Label, Value: fakeToken("user", e.Value) + "@example.com"})
+	}
+	phones := make([]model.LabeledValue, 0, len(c.Phones))
+	for _, p := range c.Phones {
+		phones = append(phones, model.LabeledValue{Label: p.Label, Value: fakePhone(p.Value)})
+	}
+	if len(c.Emails) > 0 {
+		c.Emails = emails
+	}
+	if len(c.Phones) > 0 {
+		c.Phones = phones
+	}
+
+	c.Company = fakeToken("company", c.Company)
+	c.Role = fakeToken("role", c.Role)
+	c.Location = fakeToken("location", c.Location)
+	c.Label = fakeToken("label", c.Label)
+	c.Notes = fakeToken("notes", c.Notes)
+	c.LinkedIn = fakeURL("linkedin", c.LinkedIn)
+	c.Twitter = fakeURL("twitter", c.Twitter)
+	c.Website = fakeURL("website", c.Website)
+	c.Avatar = "" // a photo identifies the person; there's no fake to swap in
+	if c.Birthday != "" {
+		// Keep the format (with or without a year) but not the date
+		h := fakeHash("birthday", c.Birthday)
+		month, day := h%12+1, (h>>8)%28+1
+		if strings.Count(c.Birthday, "-") >= 2 {
+			c.Birthday = fmt.Sprintf("%04d-%02d-%02d", 1950+(h>>16)%50, month, day)
+		} else {
+			c.Birthday = fmt.Sprintf("%02d-%02d", month, day)
+		}
+	}
+
+	// Related names are the fakes of the related contacts' real names, so they
+	// still line up with those contacts' anonymized titles
+	labels := make([]string, 0, len(c.RelatedContactLabels))
+	for _, l := range c.RelatedContactLabels {
+		labels = append(labels, fakeName(l))
+	}
+	if len(labels) > 0 {
+		c.RelatedContactLabels = labels
+	}
+
+	if c.FilePath != "" {
+		c.FilePath = filepath.Base(parser.GenerateFilePath("", c))
+	}
+	c.Content = ""
+	return c
+}
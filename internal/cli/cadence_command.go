@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+func cadenceCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "cadence",
+		Usage:       "apeople cadence <id>",
+		Description: "Compare actual interaction cadence to the configured frequency",
+		Run: func(cmd *Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("usage: apeople cadence <id>")
+			}
+
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+
+			contact := parser.FindContactByID(contacts, args[0])
+			if contact == nil {
+				return fmt.Errorf("contact not found: %s", args[0])
+			}
+
+			cadence := model.AnalyzeCadence(parser.ParseInteractionLog(contact.Content), contact.GetFrequencyDays())
+
+			if globalFlags.JSON {
+				out := struct {
+					IndexID int    `json:"index_id"`
+					ID      string `json:"id"`
+					Title   string `json:"title"`
+					model.Cadence
+				}{contact.IndexID, contact.ID, contact.Title, cadence}
+				data, _ := marshalJSON(out)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			fmt.Printf("# Cadence for %s (#%d)\n\n", contact.Title, contact.IndexID)
+			fmt.Printf("  Interactions:     %d\n", cadence.Interactions)
+			if cadence.TotalMinutes > 0 {
+				fmt.Printf("  Time logged:      %s\n", formatMinutes(cadence.TotalMinutes))
+			}
+			if cadence.Interactions < 2 {
+				fmt.Println("\n  Not enough logged interactions to compute intervals.")
+				return nil
+			}
+			fmt.Printf("  Average interval: %.1f days\n", cadence.AverageIntervalDays)
+			fmt.Printf("  Longest gap:      %d days (%s → %s)\n", cadence.LongestGapDays,
+				cadence.LongestGapStart.Format("2006-01-02"), cadence.LongestGapEnd.Format("2006-01-02"))
+
+			if cadence.FrequencyDays == 0 {
+				fmt.Printf("  Frequency:        none (%s)\n", contact.RelationshipType)
+				return nil
+			}
+			fmt.Printf("  Frequency:        %d days\n", cadence.FrequencyDays)
+			switch cadence.Comparison {
+			case "faster":
+				fmt.Println("  Verdict:          more often than configured")
+			case "slower":
+				fmt.Println("  Verdict:          less often than configured")
+			default:
+				fmt.Println("  Verdict:          on pace with configured frequency")
+			}
+			return nil
+		},
+	}
+}
@@ -3,12 +3,36 @@ package cli
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
 	"github.com/mph-llm-experiments/apeople/internal/ui"
+	"github.com/muesli/termenv"
 )
 
+// Version is the application version, set by main.
+var Version = "dev"
+
+// RunVersion prints the version when args ask for it (--version, -v or the
+// version command) and reports whether they did. main calls it before loading
+// the config, so a broken config file never gets in the way of the version.
+func RunVersion(args []string) (bool, error) {
+	remaining, err := ParseGlobalFlags(args)
+	if err != nil {
+		return false, err
+	}
+	if !globalFlags.Version && (len(remaining) != 1 || remaining[0] != "version") {
+		return false, nil
+	}
+	return true, versionCommand().Execute(nil)
+}
+
 // Run executes the CLI with the given config and arguments.
 func Run(cfg *config.Config, args []string) error {
 	remaining, err := ParseGlobalFlags(args)
@@ -16,6 +40,10 @@ func Run(cfg *config.Config, args []string) error {
 		return err
 	}
 
+	if globalFlags.Version {
+		return versionCommand().Execute(nil)
+	}
+
 	// Reload config if --config flag was provided
 	if globalFlags.Config != "" {
 		newCfg, err := config.Load(globalFlags.Config)
@@ -25,14 +53,62 @@ func Run(cfg *config.Config, args []string) error {
 		cfg = newCfg
 	}
 
-	// Override contacts directory if --dir flag was provided
+	// Override contacts directory if --dir flag was provided. A vault name
+	// from config wins over a path of the same name.
 	if globalFlags.Dir != "" {
 		cfg.ContactsDirectory = globalFlags.Dir
+		cfg.DirectorySource = "--dir"
+		if dir, ok := cfg.VaultDir(globalFlags.Dir); ok {
+			cfg.ContactsDirectory = dir
+			cfg.DirectorySource = "--dir (vault " + globalFlags.Dir + ")"
+		}
 	}
 
-	// Also check APEOPLE_DIR env var
+	// Also check APEOPLE_DIR env var, which takes vault names too
 	if envDir := os.Getenv("APEOPLE_DIR"); envDir != "" && globalFlags.Dir == "" {
 		cfg.ContactsDirectory = envDir
+		cfg.DirectorySource = "APEOPLE_DIR"
+		if dir, ok := cfg.VaultDir(envDir); ok {
+			cfg.ContactsDirectory = dir
+			cfg.DirectorySource = "APEOPLE_DIR (vault " + envDir + ")"
+		}
+	}
+
+	// With no arguments, run the configured default command
+	if len(remaining) == 0 && cfg.DefaultCommand != "tui" {
+		if remaining, err = ParseGlobalFlags(strings.Fields(cfg.DefaultCommand)); err != nil {
+			return err
+		}
+	}
+
+	// All date math and formatting goes through time.Local
+	if cfg.Location != nil {
+		time.Local = cfg.Location
+	}
+	policy := model.DefaultPolicy()
+	var customTypes []model.RelationshipType
+	for name, days := range cfg.RelationshipTypes {
+		t := model.RelationshipType(name)
+		customTypes = append(customTypes, t)
+		if days > 0 {
+			policy.Frequencies[t] = days
+		} else {
+			delete(policy.Frequencies, t)
+		}
+	}
+	sort.Slice(customTypes, func(i, j int) bool { return customTypes[i] < customTypes[j] })
+	model.SetCustomRelationshipTypes(customTypes)
+	policy.MinFrequencyDays = cfg.MinFrequencyDays
+	policy.MaxFrequencyDays = cfg.MaxFrequencyDays
+	model.SetPolicy(policy)
+	parser.SetContactTag(cfg.ContactTag)
+	parser.SetLogByYear(cfg.LogByYear)
+	parser.SetSectionHeaders(cfg.InteractionLogHeader, cfg.NotesHeader)
+	if err := parser.SetNewContactTemplate(cfg.NewContactTemplate); err != nil {
+		return err
+	}
+	if err := parser.SetFrontmatterFormat(cfg.FrontmatterFormat); err != nil {
+		return err
 	}
 
 	// Sync on startup/shutdown — skip for --json (programmatic/aweb use)
@@ -41,9 +117,17 @@ func Run(cfg *config.Config, args []string) error {
 		defer SyncOnShutdown(cfg)
 	}
 
+	// Without a terminal (piped, CI) the TUI can't run; list instead
+	if len(remaining) == 0 && !(isTerminal(os.Stdin) && isTerminal(os.Stdout)) {
+		remaining = []string{"list"}
+	}
+
 	// If no arguments, launch TUI
 	if len(remaining) == 0 {
-		m := ui.NewModel(cfg.ContactsDirectory)
+		if !colorEnabled() {
+			lipgloss.SetColorProfile(termenv.Ascii)
+		}
+		m := ui.NewModel(cfg)
 		p := tea.NewProgram(m, tea.WithAltScreen())
 		if _, err := p.Run(); err != nil {
 			return fmt.Errorf("TUI error: %w", err)
@@ -55,38 +139,102 @@ func Run(cfg *config.Config, args []string) error {
 	root := &Command{
 		Name:  "apeople",
 		Usage: "apeople <command> [options]",
-		Description: `Agent-first contacts management.
+		Description: `apeople v` + Version + ` — agent-first contacts management.
 
 Commands:
   list       List contacts
   show       Show contact details
+  pick       index_id<TAB>title lines for fzf, and resolving the pick
+  qr         Show a contact as a QR code to scan into a phone
   new        Create a new contact
   update     Update contact fields
+  tag        Add or remove a tag on every contact matching a filter
   log        Log an interaction
+  promote    Move a contact up the type ladder (e.g. network → work)
+  demote     Move a contact down the type ladder
   bump       Bump a contact (review without contacting)
-  delete     Delete a contact
+  cadence    Compare interaction cadence to frequency
+  log-reorganize  Group a contact's interaction log by year
+  neglected  Most overdue periodic contacts
+  aging      Overdue contacts bucketed by days past due
+  digest     Overdue, due-soon and birthday summary for email (text or HTML)
+  notify     Desktop notifications for contacts overdue or due today
+  companies  Contacts grouped by company
+  delete     Delete a contact (to the trash unless --hard)
+  restore-deleted  Restore a contact from the trash
+  import     Import contacts from a JSON array
+  report     Markdown/HTML report of all contacts
+  export     Export contacts as CSV (generic, Google, Apple) or NDJSON
+  dump       Dump all contacts as one JSON file
+  load       Recreate contacts from a dump
+  relations  Maintain cached relationship data
+  tasks check  Find contacts in task states with no open task (--fix creates them)
+  reindex-counter  Repair the index ID counter after deletions or edits
+  fix-ids    Reassign duplicate index IDs
   sync       Sync files with Cloudflare R2
   migrate    Migrate from Denote format to acore format
+  upgrade    Migrate contact files to the current frontmatter schema
+  sync-computed  Write computed fields into frontmatter for other tools
+  config     Show the active config file and contacts directory
+  schema     JSON Schema for the contact JSON format
+  doctor     Check the contacts directory for problems
+  version    Show version information
 
 Global Options:
   --config PATH  Use specific config file
-  --dir PATH     Override contacts directory
+  --dir PATH     Override contacts directory (or a [vaults] name)
   --json         Output in JSON format
+  --compact      Single-line JSON output
   --no-color     Disable color output
-  --quiet, -q    Minimal output`,
+  --quiet, -q    Minimal output
+  --version      Show version information`,
 	}
 
 	root.Subcommands = append(root.Subcommands,
 		listCommand(cfg),
 		showCommand(cfg),
+		pickCommand(cfg),
+		qrCommand(cfg),
 		newCommand(cfg),
 		updateCommand(cfg),
+		tagCommand(cfg),
 		logCommand(cfg),
+		promoteCommand(cfg),
+		demoteCommand(cfg),
 		bumpCommand(cfg),
+		cadenceCommand(cfg),
+		logReorganizeCommand(cfg),
+		neglectedCommand(cfg),
+		agingCommand(cfg),
+		digestCommand(cfg),
+		notifyCommand(cfg),
+		companiesCommand(cfg),
 		deleteCommand(cfg),
+		restoreDeletedCommand(cfg),
+		importCommand(cfg),
+		reportCommand(cfg),
+		exportCommand(cfg),
+		dumpCommand(cfg),
+		loadCommand(cfg),
+		relationsCommand(cfg),
+		tasksCommand(cfg),
+		reindexCounterCommand(cfg),
+		fixIDsCommand(cfg),
 		syncCommand(cfg),
 		migrateCommand(cfg),
+		upgradeCommand(cfg),
+		syncComputedCommand(cfg),
+		configCommand(cfg),
+		doctorCommand(cfg),
+		schemaCommand(),
+		versionCommand(),
 	)
 
 	return root.Execute(remaining)
 }
+
+// isTerminal reports whether f is a character device such as a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
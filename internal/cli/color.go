@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// ANSI foreground colors used in text output
+const (
+	colorRed    = "31"
+	colorYellow = "33"
+	colorGreen  = "32"
+)
+
+// colorEnabled reports whether text output may use ANSI colors. It is the
+// single place this is decided: never with --no-color or when NO_COLOR is
+// set to anything (https://no-color.org), and only when stdout is a
+// terminal.
+func colorEnabled() bool {
+	if globalFlags.NoColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return term.IsTerminal(os.Stdout.Fd())
+}
+
+// colorize wraps s in the given ANSI color when color is enabled. Pad s to
+// its column width first: the escape codes have no width of their own but
+// would count towards fmt's padding.
+func colorize(color, s string) string {
+	if color == "" || !colorEnabled() {
+		return s
+	}
+	return "\x1b[" + color + "m" + s + "\x1b[0m"
+}
+
+// statusColor returns the color for a contact's overdue status, or "" for
+// none.
+func statusColor(status string) string {
+	switch status {
+	case "overdue":
+		return colorRed
+	case "attention":
+		return colorYellow
+	case "good", "scheduled":
+		return colorGreen
+	}
+	return ""
+}
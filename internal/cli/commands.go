@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -31,6 +33,9 @@ func (c *Command) Execute(args []string) error {
 	if c.Flags != nil {
 		reordered := reorderFlagsFirst(args, c.Flags)
 		if err := c.Flags.Parse(reordered); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil // -h printed the usage; that's not a failure
+			}
 			return err
 		}
 		args = c.Flags.Args()
@@ -120,13 +125,36 @@ func isBoolFlag(f *flag.Flag) bool {
 	return false
 }
 
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// splitLabeled splits a "label:value" flag argument. Without a colon the whole
+// argument is the value and the label is empty.
+func splitLabeled(arg string) (label, value string) {
+	if idx := strings.Index(arg, ":"); idx >= 0 {
+		return strings.TrimSpace(arg[:idx]), strings.TrimSpace(arg[idx+1:])
+	}
+	return "", strings.TrimSpace(arg)
+}
+
 // GlobalFlags holds global CLI flags.
 type GlobalFlags struct {
 	Config  string
 	Dir     string
 	NoColor bool
 	JSON    bool
+	Compact bool
 	Quiet   bool
+	Version bool
 }
 
 var globalFlags GlobalFlags
@@ -136,6 +164,15 @@ func GetGlobalFlags() *GlobalFlags {
 	return &globalFlags
 }
 
+// marshalJSON encodes command output: indented by default, one line with
+// --compact.
+func marshalJSON(v interface{}) ([]byte, error) {
+	if globalFlags.Compact {
+		return json.Marshal(v)
+	}
+	return json.MarshalIndent(v, "", "  ")
+}
+
 // ParseGlobalFlags extracts global flags from args, returning remaining args.
 func ParseGlobalFlags(args []string) ([]string, error) {
 	var remaining []string
@@ -165,10 +202,18 @@ func ParseGlobalFlags(args []string) ([]string, error) {
 			globalFlags.JSON = true
 			i++
 			continue
+		case "--compact":
+			globalFlags.Compact = true
+			i++
+			continue
 		case "--quiet", "-q":
 			globalFlags.Quiet = true
 			i++
 			continue
+		case "--version", "-version", "-v":
+			globalFlags.Version = true
+			i++
+			continue
 		}
 
 		// --flag=value syntax
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// companyMember is one contact listed under a company.
+type companyMember struct {
+	IndexID int    `json:"index_id"`
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Role    string `json:"role,omitempty"`
+	State   string `json:"state,omitempty"`
+}
+
+func companiesCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("companies", flag.ContinueOnError)
+	company := fs.String("company", "", "Only show this company (case-insensitive)")
+	all := fs.Bool("all", false, "Include archived contacts")
+
+	return &Command{
+		Name:        "companies",
+		Usage:       "apeople companies [--company name] [--all]",
+		Description: "Group contacts by company",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+			contacts = model.FilterContacts(contacts, model.ContactFilter{IncludeArchived: *all})
+
+			companies := groupByCompany(contacts)
+			if *company != "" {
+				var found bool
+				for name, members := range companies {
+					if strings.EqualFold(name, strings.TrimSpace(*company)) {
+						companies = map[string][]companyMember{name: members}
+						found = true
+						break
+					}
+				}
+				if !found {
+					return fmt.Errorf("no contacts at company: %s", *company)
+				}
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(companies)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if len(companies) == 0 {
+				fmt.Println("No contacts have a company set.")
+				return nil
+			}
+
+			// Largest companies first, then alphabetical
+			names := make([]string, 0, len(companies))
+			for name := range companies {
+				names = append(names, name)
+			}
+			sort.Slice(names, func(i, j int) bool {
+				a, b := companies[names[i]], companies[names[j]]
+				if len(a) != len(b) {
+					return len(a) > len(b)
+				}
+				return strings.ToLower(names[i]) < strings.ToLower(names[j])
+			})
+
+			for i, name := range names {
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("%s (%d)\n", name, len(companies[name]))
+				for _, m := range companies[name] {
+					line := fmt.Sprintf("  #%-4d %s", m.IndexID, m.Title)
+					if m.Role != "" {
+						line += " — " + m.Role
+					}
+					fmt.Println(line)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// groupByCompany maps each company to its contacts, in the contacts' order.
+// Company names are matched case-insensitively; the first spelling seen is
+// used as the key. Contacts without a company are left out.
+func groupByCompany(contacts []model.Contact) map[string][]companyMember {
+	companies := map[string][]companyMember{}
+	spelling := map[string]string{}
+	for _, c := range contacts {
+		company := strings.TrimSpace(c.Company)
+		if company == "" {
+			continue
+		}
+		key := strings.ToLower(company)
+		if _, ok := spelling[key]; !ok {
+			spelling[key] = company
+		}
+		name := spelling[key]
+		companies[name] = append(companies[name], companyMember{
+			IndexID: c.IndexID,
+			ID:      c.ID,
+			Title:   c.Title,
+			Role:    c.Role,
+			State:   c.State,
+		})
+	}
+	return companies
+}
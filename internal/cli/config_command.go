@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// activeConfig describes the resolved configuration.
+type activeConfig struct {
+	ConfigFile      string            `json:"config_file"`
+	ConfigSource    string            `json:"config_source"`
+	Legacy          bool              `json:"legacy"`
+	Directory       string            `json:"contacts_directory"`
+	DirectorySource string            `json:"directory_source"`
+	DirectoryExists bool              `json:"directory_exists"`
+	Contacts        int               `json:"contacts"`
+	ContactTag      string            `json:"contact_tag"`
+	Vaults          map[string]string `json:"vaults,omitempty"`
+}
+
+func configCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "config",
+		Usage:       "apeople config",
+		Description: "Show which config file and contacts directory are in use",
+		Run: func(cmd *Command, args []string) error {
+			info := activeConfig{
+				ConfigFile:      cfg.Path,
+				ConfigSource:    cfg.Source,
+				Legacy:          cfg.Source == config.SourceLegacy,
+				Directory:       cfg.ContactsDirectory,
+				DirectorySource: cfg.DirectorySource,
+				ContactTag:      cfg.ContactTag,
+				Vaults:          cfg.Vaults,
+			}
+			if st, err := os.Stat(cfg.ContactsDirectory); err == nil && st.IsDir() {
+				info.DirectoryExists = true
+				err := parser.WalkContacts(cfg.ContactsDirectory, func(model.Contact) error {
+					info.Contacts++
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(info)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if info.ConfigFile == "" {
+				fmt.Println("Config file:   none, using defaults")
+			} else {
+				fmt.Printf("Config file:   %s (%s)\n", info.ConfigFile, info.ConfigSource)
+			}
+			if info.Legacy {
+				fmt.Println("               legacy denote-contacts config; consider moving it to apeople/config.toml")
+			}
+			fmt.Printf("Contacts dir:  %s (from %s)\n", info.Directory, info.DirectorySource)
+			if !info.DirectoryExists {
+				fmt.Println("               does not exist")
+			} else {
+				fmt.Printf("Contacts:      %d files tagged __%s\n", info.Contacts, info.ContactTag)
+			}
+			if len(info.Vaults) > 0 {
+				names := make([]string, 0, len(info.Vaults))
+				for name := range info.Vaults {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				fmt.Println("Vaults (--dir <name>):")
+				for _, name := range names {
+					fmt.Printf("  %-12s %s\n", name, info.Vaults[name])
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +17,7 @@ import (
 	"github.com/mph-llm-experiments/apeople/internal/config"
 	"github.com/mph-llm-experiments/apeople/internal/model"
 	"github.com/mph-llm-experiments/apeople/internal/parser"
+	"github.com/mph-llm-experiments/apeople/internal/tasks"
 )
 
 func listCommand(cfg *config.Config) *Command {
@@ -23,10 +28,25 @@ func listCommand(cfg *config.Config) *Command {
 	overdue := fs.Bool("overdue", false, "Show only overdue contacts")
 	engaged := fs.Bool("engaged", false, "Show contacts in any engagement state (not ok, not archived)")
 	tag := fs.String("tag", "", "Filter by tag")
-	search := fs.String("search", "", "Search contacts by name, company, email, or tags")
+	search := fs.String("search", "", "Search contacts by name, company, role, label, email, or tags (#tag for tags only)")
+	searchContent := fs.Bool("search-content", false, "Also match --search against the body, including interaction notes")
 	plannedFor := fs.String("planned-for", "", "Filter by planned_for date (today, YYYY-MM-DD, or any)")
+	relatedTask := fs.String("related-task", "", "Only contacts linked to this task ULID")
+	relatedIdea := fs.String("related-idea", "", "Only contacts linked to this idea ULID")
+	relatedPerson := fs.String("related-person", "", "Only contacts linked to this contact ULID")
+	empty := fs.Bool("empty", false, "Only contacts with no interaction log, notes or other body text")
+	linksTo := fs.String("links-to", "", "Only contacts referencing this ULID in a related_* list or as a [[ULID]] link in the body")
+	var missing stringList
+	fs.Var(&missing, "missing", "Only contacts lacking this field, e.g. email, phone, company (repeatable; all must be missing)")
 	all := fs.Bool("all", false, "Show all contacts including archived")
-	sortBy := fs.String("sort", "name", "Sort by: name, days, type, state")
+	sortBy := fs.String("sort", "name", "Sort by: name, days, type, state, warmth (ties broken by --sort2, then name)")
+	sortBy2 := fs.String("sort2", "", "Tie-breaker for --sort: name, days, type, state, warmth")
+	groupBy := fs.String("group-by", "", "Group output under headers by: type, state")
+	tmplText, tmplFile := templateFlags(fs)
+	relative := fs.Bool("relative", cfg.RelativeDates, "Show days since contact as relative time (e.g. \"3 weeks ago\")")
+	jsonl := fs.Bool("jsonl", false, "Output one JSON contact per line (JSON Lines), ignoring --group-by")
+	raw := fs.Bool("raw", false, "With --json or --jsonl, output only stored fields, leaving out computed ones like days_since_contact")
+	width := fs.Int("width", 0, "Table width in columns (default: the terminal's width)")
 
 	return &Command{
 		Name:        "list",
@@ -34,99 +54,145 @@ func listCommand(cfg *config.Config) *Command {
 		Description: "List contacts with optional filtering",
 		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
-			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			tmpl, err := parseTemplate(*tmplText, *tmplFile)
 			if err != nil {
 				return err
 			}
+			if *raw && !globalFlags.JSON && !*jsonl {
+				return fmt.Errorf("--raw needs --json or --jsonl")
+			}
 
-			contacts, err = parser.AssignIndexIDs(cfg.ContactsDirectory, contacts)
+			var missingFields []string
+			for _, arg := range missing {
+				for _, field := range strings.Split(arg, ",") {
+					field = strings.ToLower(strings.TrimSpace(field))
+					if !model.ValidMissingField(field) {
+						return fmt.Errorf("unknown --missing field %q (use %s)", field, strings.Join(model.MissingFieldNames(), ", "))
+					}
+					missingFields = append(missingFields, field)
+				}
+			}
+
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
 			if err != nil {
 				return err
 			}
-
-			// Apply filters
-			var filtered []model.Contact
-			for _, c := range contacts {
-				if !*all && c.State == "archived" {
-					continue
-				}
-				if *relType != "" && string(c.RelationshipType) != *relType {
-					continue
-				}
-				if *state != "" && c.State != *state {
-					continue
-				}
-				if *engaged && (c.State == "" || c.State == "ok" || c.State == "archived") {
-					continue
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+
+			// Agents can opt into seeing archived contacts in JSON output;
+			// the human-facing default stays unchanged.
+			includeArchived := *all || ((globalFlags.JSON || *jsonl) && cfg.ListIncludeArchived)
+
+			filtered := model.FilterContacts(contacts, model.ContactFilter{
+				Type:            *relType,
+				State:           *state,
+				Style:           *style,
+				Tag:             *tag,
+				Search:          *search,
+				SearchContent:   *searchContent,
+				PlannedFor:      *plannedFor,
+				Overdue:         *overdue,
+				Engaged:         *engaged,
+				IncludeArchived: includeArchived,
+				RelatedTask:     *relatedTask,
+				RelatedIdea:     *relatedIdea,
+				RelatedPerson:   *relatedPerson,
+				Missing:         missingFields,
+			})
+			if *linksTo != "" {
+				linking := filtered[:0]
+				for i := range filtered {
+					if parser.LinksTo(&filtered[i], *linksTo) {
+						linking = append(linking, filtered[i])
+					}
 				}
-				if *style != "" && string(c.ContactStyle) != *style {
-					continue
+				filtered = linking
+			}
+			if *empty {
+				blank := filtered[:0]
+				for _, c := range filtered {
+					if !parser.HasContent(c) {
+						blank = append(blank, c)
+					}
 				}
-				if *overdue && !c.IsOverdue() {
-					continue
+				filtered = blank
+			}
+
+			var groupKey func(c model.Contact) string
+			switch *groupBy {
+			case "":
+			case "type":
+				groupKey = func(c model.Contact) string { return string(c.RelationshipType) }
+			case "state":
+				groupKey = func(c model.Contact) string { return c.State }
+			default:
+				return fmt.Errorf("invalid --group-by %q (use type or state)", *groupBy)
+			}
+
+			// Sort by --sort, then --sort2, then name
+			keys := []string{*sortBy}
+			if *sortBy == "" {
+				keys[0] = "name"
+			} else if listSortKeys[*sortBy] == nil {
+				return fmt.Errorf("invalid --sort %q (use %s)", *sortBy, strings.Join(listSortKeyNames, ", "))
+			}
+			if *sortBy2 != "" {
+				if listSortKeys[*sortBy2] == nil {
+					return fmt.Errorf("invalid --sort2 %q (use %s)", *sortBy2, strings.Join(listSortKeyNames, ", "))
 				}
-				if *tag != "" && !c.HasTag(*tag) {
-				continue
+				keys = append(keys, *sortBy2)
 			}
-			if *search != "" {
-					query := strings.ToLower(*search)
-					match := strings.Contains(strings.ToLower(c.Title), query) ||
-						strings.Contains(strings.ToLower(c.Company), query) ||
-						strings.Contains(strings.ToLower(c.Email), query) ||
-						strings.Contains(strings.ToLower(c.Role), query)
-					if !match {
-						for _, tag := range c.Tags {
-							if strings.Contains(strings.ToLower(tag), query) {
-								match = true
-								break
-							}
-						}
-					}
-					if !match {
-						continue
+			sortContacts(filtered, keys...)
+
+			if tmpl != nil {
+				return renderTemplate(tmpl, filtered)
+			}
+			if *jsonl {
+				enc := json.NewEncoder(os.Stdout)
+				for _, c := range filtered {
+					var v interface{} = c
+					if *raw {
+						v = storedContact{Contact: &c}
 					}
-				}
-				if *plannedFor != "" {
-					switch strings.ToLower(*plannedFor) {
-					case "any":
-						if c.PlannedFor == "" {
-							continue
-						}
-					case "today":
-						if c.PlannedFor != time.Now().Format("2006-01-02") {
-							continue
-						}
-					default:
-						if c.PlannedFor != *plannedFor {
-							continue
-						}
+					if err := enc.Encode(v); err != nil {
+						return fmt.Errorf("failed to marshal JSON: %w", err)
 					}
 				}
-				filtered = append(filtered, c)
+				return nil
 			}
 
-			// Sort
-			switch *sortBy {
-			case "days":
-				sort.Slice(filtered, func(i, j int) bool {
-					return filtered[i].DaysSinceContact() > filtered[j].DaysSinceContact()
-				})
-			case "type":
-				sort.Slice(filtered, func(i, j int) bool {
-					return string(filtered[i].RelationshipType) < string(filtered[j].RelationshipType)
-				})
-			case "state":
-				sort.Slice(filtered, func(i, j int) bool {
-					return filtered[i].State < filtered[j].State
-				})
-			default: // "name"
-				sort.Slice(filtered, func(i, j int) bool {
-					return strings.ToLower(filtered[i].Title) < strings.ToLower(filtered[j].Title)
-				})
+			// Group, keeping the sort order within each group
+			groups := map[string][]model.Contact{}
+			var groupNames []string
+			if groupKey != nil {
+				for _, c := range filtered {
+					key := groupKey(c)
+					if key == "" {
+						key = "-"
+					}
+					if _, ok := groups[key]; !ok {
+						groupNames = append(groupNames, key)
+					}
+					groups[key] = append(groups[key], c)
+				}
+				sort.Strings(groupNames)
 			}
 
 			if globalFlags.JSON {
-				data, err := json.MarshalIndent(filtered, "", "  ")
+				var out interface{} = filtered
+				switch {
+				case groupKey != nil && *raw:
+					stored := map[string][]storedContact{}
+					for name, cs := range groups {
+						stored[name] = storedContacts(cs)
+					}
+					out = stored
+				case groupKey != nil:
+					out = groups
+				case *raw:
+					out = storedContacts(filtered)
+				}
+				data, err := marshalJSON(out)
 				if err != nil {
 					return fmt.Errorf("failed to marshal JSON: %w", err)
 				}
@@ -140,84 +206,177 @@ func listCommand(cfg *config.Config) *Command {
 			}
 
 			// Text output
-			fmt.Printf("%-4s %-22s %5s  %-10s %-10s %-20s %s\n",
-				"#", "NAME", "DAYS", "TYPE", "STATE", "COMPANY", "TAGS")
-			fmt.Println(strings.Repeat("-", 90))
-
-			for _, c := range filtered {
-				days := c.DaysSinceContact()
-				daysStr := "-"
-				if days >= 0 {
-					daysStr = fmt.Sprintf("%d", days)
+			daysWidth, daysHeader := 5, "DAYS"
+			if *relative {
+				daysWidth, daysHeader = 13, "LAST CONTACT"
+			} else if slices.ContainsFunc(filtered, func(c model.Contact) bool { return c.IsScheduled() }) {
+				daysWidth = len("scheduled")
+			}
+			tableWidth := *width
+			if tableWidth == 0 {
+				tableWidth = terminalWidth()
+			}
+			layout := newListLayout(tableWidth, daysWidth)
+			fmt.Printf("%-4s %-*s %*s  %-10s %-10s %-*s %s\n",
+				"#", layout.Name, "NAME", layout.Days, daysHeader, "TYPE", "STATE", layout.Company, "COMPANY", "TAGS")
+			fmt.Println(strings.Repeat("-", layout.Width()))
+
+			if groupKey == nil {
+				printListRows(filtered, layout, *relative)
+				return nil
+			}
+			for i, name := range groupNames {
+				overdueCount := 0
+				for _, c := range groups[name] {
+					if c.IsOverdue() {
+						overdueCount++
+					}
 				}
-
-				name := c.Title
-				if len(name) > 22 {
-					name = name[:19] + "..."
+				if i > 0 {
+					fmt.Println()
 				}
+				fmt.Printf("== %s (%d, %d overdue) ==\n", name, len(groups[name]), overdueCount)
+				printListRows(groups[name], layout, *relative)
+			}
+			return nil
+		},
+	}
+}
 
-				company := c.Company
-				if len(company) > 20 {
-					company = company[:17] + "..."
-				}
+// storedContact is a contact as list --raw prints it: only the fields stored
+// in the file. Each field below masks the computed field of the same JSON
+// name on the embedded contact and is always nil, so omitempty drops it.
+type storedContact struct {
+	*model.Contact
+	FilePath      *string `json:"file_path,omitempty"`
+	DaysSince     *int    `json:"days_since_contact,omitempty"`
+	Scheduled     *bool   `json:"scheduled,omitempty"`
+	OverdueStatus *string `json:"overdue_status,omitempty"`
+	Warmth        *int    `json:"warmth,omitempty"`
+	Archived      *bool   `json:"archived,omitempty"`
+}
 
-				var tagStrs []string
-				for _, t := range c.Tags {
-					if t != "contact" {
-						tagStrs = append(tagStrs, "#"+t)
-					}
-				}
+func storedContacts(contacts []model.Contact) []storedContact {
+	stored := make([]storedContact, len(contacts))
+	for i := range contacts {
+		stored[i] = storedContact{Contact: &contacts[i]}
+	}
+	return stored
+}
 
-				stateStr := c.State
-				if stateStr == "" {
-					stateStr = "-"
-				}
+// printListRows prints one table row per contact in the `list` column layout.
+func printListRows(contacts []model.Contact, layout listLayout, relative bool) {
+	for _, c := range contacts {
+		days := c.DaysSinceContact()
+		daysStr := "-"
+		switch {
+		case c.IsScheduled():
+			daysStr = "scheduled"
+			if relative {
+				daysStr = relativeDays(days)
+			}
+		case days >= 0:
+			daysStr = fmt.Sprintf("%d", days)
+			if relative {
+				daysStr = relativeDays(days)
+			}
+		}
 
-				typeStr := string(c.RelationshipType)
-				if typeStr == "" {
-					typeStr = "-"
-				}
+		name := truncate(c.Title, layout.Name)
+		company := truncate(c.Company, layout.Company)
 
-				fmt.Printf("%-4d %-22s %5s  %-10s %-10s %-20s %s\n",
-					c.IndexID, name, daysStr, typeStr, stateStr, company, strings.Join(tagStrs, " "))
+		var tagStrs []string
+		for _, t := range c.Tags {
+			if t != parser.ContactTag() {
+				tagStrs = append(tagStrs, "#"+t)
 			}
+		}
 
-			return nil
-		},
+		stateStr := c.State
+		if stateStr == "" {
+			stateStr = "-"
+		}
+
+		typeStr := string(c.RelationshipType)
+		if typeStr == "" {
+			typeStr = "-"
+		}
+
+		daysStr = colorize(statusColor(c.OverdueStatus), fmt.Sprintf("%*s", layout.Days, daysStr))
+
+		fmt.Printf("%-4d %-*s %s  %-10s %-10s %-*s %s\n",
+			c.IndexID, layout.Name, name, daysStr, typeStr, stateStr, layout.Company, company, strings.Join(tagStrs, " "))
 	}
 }
 
 func showCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	format := fs.String("format", "text", "Output format: text, markdown")
+	relative := fs.Bool("relative", cfg.RelativeDates, "Show dates as relative time (e.g. \"3 weeks ago\", \"in 5 days\")")
+	logLimit := fs.Int("log-limit", 0, "Show only the N most recent interaction log entries (0 for all)")
+	tmplText, tmplFile := templateFlags(fs)
+
 	return &Command{
 		Name:        "show",
-		Usage:       "apeople show <id>",
+		Usage:       "apeople show <id> [--format text|markdown] [--log-limit N] [--template text|--template-file path]",
 		Description: "Show contact details by index_id or ULID",
+		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
 			if len(args) == 0 {
 				return fmt.Errorf("usage: apeople show <id>")
 			}
-
-			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			tmpl, err := parseTemplate(*tmplText, *tmplFile)
 			if err != nil {
 				return err
 			}
-			contacts, err = parser.AssignIndexIDs(cfg.ContactsDirectory, contacts)
+
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
 			if err != nil {
 				return err
 			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
 
 			contact := parser.FindContactByID(contacts, args[0])
 			if contact == nil {
 				return fmt.Errorf("contact not found: %s", args[0])
 			}
+			if tmpl != nil {
+				return renderTemplate(tmpl, []model.Contact{*contact})
+			}
+
+			interactions := parser.ParseInteractionLog(contact.Content)
+			summary := model.SummarizeInteractions(interactions)
+			related, unresolved := resolveRelatedPeople(contact, contacts)
+			rank, overdueTotal := overdueRank(contacts, contact.ID)
 
 			if globalFlags.JSON {
 				type contactWithContent struct {
 					*model.Contact
-					Content string `json:"content,omitempty"`
+					Content               string                    `json:"content,omitempty"`
+					Interactions          []model.Interaction       `json:"interactions,omitempty"`
+					InteractionSummary    *model.InteractionSummary `json:"interaction_summary,omitempty"`
+					RelatedPeopleResolved []relatedPerson           `json:"related_people_resolved"`
+					FrequencyDays         int                       `json:"frequency_days,omitempty"`
+					FrequencySource       string                    `json:"frequency_source,omitempty"`
+					OverdueRank           int                       `json:"overdue_rank,omitempty"`
+					OverdueTotal          int                       `json:"overdue_total"`
+				}
+				out := contactWithContent{
+					Contact:               contact,
+					Content:               strings.TrimSpace(contact.Content),
+					Interactions:          interactions,
+					RelatedPeopleResolved: related,
+					FrequencyDays:         contact.GetFrequencyDays(),
+					OverdueRank:           rank,
+					OverdueTotal:          overdueTotal,
+				}
+				if out.FrequencyDays > 0 {
+					out.FrequencySource = frequencySource(contact)
 				}
-				out := contactWithContent{Contact: contact, Content: strings.TrimSpace(contact.Content)}
-				data, err := json.MarshalIndent(out, "", "  ")
+				if summary.Total > 0 {
+					out.InteractionSummary = &summary
+				}
+				data, err := marshalJSON(out)
 				if err != nil {
 					return fmt.Errorf("failed to marshal JSON: %w", err)
 				}
@@ -225,14 +384,26 @@ func showCommand(cfg *config.Config) *Command {
 				return nil
 			}
 
+			switch *format {
+			case "text":
+			case "markdown", "md":
+				fmt.Print(renderContactMarkdown(contact, contacts, *logLimit))
+				return nil
+			default:
+				return fmt.Errorf("unsupported format: %s (use text or markdown)", *format)
+			}
+
 			// Text output
 			fmt.Printf("# %s (#%d)\n\n", contact.Title, contact.IndexID)
+			if len(contact.Aliases) > 0 {
+				fmt.Printf("  Aliases:   %s\n", strings.Join(contact.Aliases, ", "))
+			}
 
-			if contact.Email != "" {
-				fmt.Printf("  Email:     %s\n", contact.Email)
+			for _, e := range contact.AllEmails() {
+				fmt.Printf("  %-10s %s\n", labeledHeading("Email", e.Label)+":", e.Value)
 			}
-			if contact.Phone != "" {
-				fmt.Printf("  Phone:     %s\n", contact.Phone)
+			for _, p := range contact.AllPhones() {
+				fmt.Printf("  %-10s %s\n", labeledHeading("Phone", p.Label)+":", p.Value)
 			}
 			if contact.Company != "" {
 				fmt.Printf("  Company:   %s\n", contact.Company)
@@ -243,11 +414,18 @@ func showCommand(cfg *config.Config) *Command {
 			if contact.Location != "" {
 				fmt.Printf("  Location:  %s\n", contact.Location)
 			}
+			// Files saved before links were normalized may still hold handles
 			if contact.LinkedIn != "" {
-				fmt.Printf("  LinkedIn:  %s\n", contact.LinkedIn)
+				fmt.Printf("  LinkedIn:  %s\n", model.NormalizeLinkedIn(contact.LinkedIn))
+			}
+			if contact.Twitter != "" {
+				fmt.Printf("  Twitter:   %s\n", model.NormalizeTwitter(contact.Twitter))
 			}
 			if contact.Website != "" {
-				fmt.Printf("  Website:   %s\n", contact.Website)
+				fmt.Printf("  Website:   %s\n", model.NormalizeWebsite(contact.Website))
+			}
+			if contact.Avatar != "" {
+				fmt.Printf("  Avatar:    %s\n", contact.Avatar)
 			}
 			fmt.Println()
 
@@ -264,13 +442,24 @@ func showCommand(cfg *config.Config) *Command {
 
 			freq := contact.GetFrequencyDays()
 			if freq > 0 {
-				fmt.Printf("  Frequency: %d days\n", freq)
+				fmt.Printf("  Frequency: %d days (%s)\n", freq, frequencySource(contact))
+			}
+			if contact.ReminderLeadDays != nil {
+				fmt.Printf("  Lead time: %d days before due\n", *contact.ReminderLeadDays)
 			}
+			fmt.Printf("  Warmth:    %d/100\n", contact.Warmth)
 			fmt.Println()
 
 			days := contact.DaysSinceContact()
-			if days >= 0 {
-				fmt.Printf("  Last contacted: %d days ago", days)
+			if contact.IsScheduled() {
+				fmt.Printf("  Last contacted: scheduled for %s (%s)\n",
+					contact.LastContacted.Format("2006-01-02"), relativeDays(days))
+			} else if days >= 0 {
+				if *relative {
+					fmt.Printf("  Last contacted: %s", relativeDays(days))
+				} else {
+					fmt.Printf("  Last contacted: %d days ago", days)
+				}
 				if contact.LastInteractionType != "" {
 					fmt.Printf(" (%s)", contact.LastInteractionType)
 				}
@@ -278,8 +467,40 @@ func showCommand(cfg *config.Config) *Command {
 			} else {
 				fmt.Println("  Last contacted: never")
 			}
+			if freq > 0 && contact.LastContacted != nil {
+				due := contact.LastContacted.AddDate(0, 0, freq).Format("2006-01-02")
+				if *relative {
+					due = relativeDays(days - freq)
+				}
+				fmt.Printf("  Next due:       %s\n", colorize(statusColor(contact.OverdueStatus), due))
+			}
+			if rank > 0 {
+				fmt.Printf("  Overdue rank:   %d of %d\n", rank, overdueTotal)
+			}
 			if contact.LastBumpDate != nil {
-				fmt.Printf("  Last bump:      %s (count: %d)\n", contact.LastBumpDate.Format("2006-01-02"), contact.BumpCount)
+				fmt.Printf("  Reviewed:       %s, last on %s\n", timesText(contact.BumpCount), contact.LastBumpDate.Format("2006-01-02"))
+			}
+			if summary.Total > 0 {
+				fmt.Printf("  Interactions:   %d (first %s, last %s)\n", summary.Total,
+					summary.First.Format("2006-01-02"), summary.Last.Format("2006-01-02"))
+				types := make([]string, 0, len(summary.ByType))
+				for t := range summary.ByType {
+					types = append(types, t)
+				}
+				sort.Slice(types, func(i, j int) bool {
+					if summary.ByType[types[i]] != summary.ByType[types[j]] {
+						return summary.ByType[types[i]] > summary.ByType[types[j]]
+					}
+					return types[i] < types[j]
+				})
+				var counts []string
+				for _, t := range types {
+					counts = append(counts, fmt.Sprintf("%s %d", t, summary.ByType[t]))
+				}
+				fmt.Printf("  By type:        %s\n", strings.Join(counts, ", "))
+				if summary.TotalMinutes > 0 {
+					fmt.Printf("  Time logged:    %s\n", formatMinutes(summary.TotalMinutes))
+				}
 			}
 
 			if contact.Created != "" {
@@ -291,7 +512,7 @@ func showCommand(cfg *config.Config) *Command {
 
 			var tagStrs []string
 			for _, t := range contact.Tags {
-				if t != "contact" {
+				if t != parser.ContactTag() {
 					tagStrs = append(tagStrs, "#"+t)
 				}
 			}
@@ -302,7 +523,14 @@ func showCommand(cfg *config.Config) *Command {
 			if len(contact.RelatedPeople) > 0 || len(contact.RelatedTasks) > 0 || len(contact.RelatedIdeas) > 0 {
 				fmt.Println()
 				if len(contact.RelatedPeople) > 0 {
-					fmt.Printf("  Related people: %s\n", strings.Join(contact.RelatedPeople, ", "))
+					var people []string
+					for _, p := range related {
+						people = append(people, fmt.Sprintf("%s (#%d)", p.Title, p.IndexID))
+					}
+					for _, id := range unresolved {
+						people = append(people, id+" (unresolved)")
+					}
+					fmt.Printf("  Related people: %s\n", strings.Join(people, ", "))
 				}
 				if len(contact.RelatedTasks) > 0 {
 					fmt.Printf("  Related tasks:  %s\n", strings.Join(contact.RelatedTasks, ", "))
@@ -312,8 +540,14 @@ func showCommand(cfg *config.Config) *Command {
 				}
 			}
 
+			// Files not yet upgraded keep notes in frontmatter
+			if notes := strings.TrimSpace(contact.Notes); notes != "" {
+				fmt.Printf("\n  Notes: %s\n", strings.ReplaceAll(notes, "\n", "\n         "))
+			}
+
 			if strings.TrimSpace(contact.Content) != "" {
-				fmt.Printf("\n---\n%s", contact.Content)
+				content, _ := parser.TruncateInteractionLog(contact.Content, *logLimit)
+				fmt.Printf("\n---\n%s", content)
 			}
 
 			return nil
@@ -323,37 +557,81 @@ func showCommand(cfg *config.Config) *Command {
 
 func newCommand(cfg *config.Config) *Command {
 	fs := flag.NewFlagSet("new", flag.ContinueOnError)
-	relType := fs.String("type", "network", "Relationship type (close, family, network, work, social, providers, recruiters)")
-	style := fs.String("style", "periodic", "Contact style (periodic, ambient, triggered)")
+	relType := fs.String("type", cfg.DefaultType, "Relationship type (close, family, network, work, social, providers, recruiters)")
+	style := fs.String("style", cfg.DefaultStyle, "Contact style (periodic, ambient, triggered)")
 	email := fs.String("email", "", "Email address")
 	phone := fs.String("phone", "", "Phone number")
 	company := fs.String("company", "", "Company name")
 	role := fs.String("role", "", "Role/title")
 	tags := fs.String("tags", "", "Comma-separated tags (in addition to 'contact')")
-	state := fs.String("state", "ok", "Contact state (ok, ping, followup, waiting, sked, archived)")
+	state := fs.String("state", cfg.DefaultState, "Contact state (ok, ping, followup, waiting, sked, scheduled, timeout, archived)")
 	location := fs.String("location", "", "Location")
+	avatar := fs.String("avatar", "", "Photo: path to a local image or a URL")
+	frequency := fs.Int("frequency", 0, "Custom contact frequency in days (default: the type's)")
+	force := fs.Bool("force", false, "Accept an unknown state")
+	var addEmails, addPhones stringList
+	fs.Var(&addEmails, "add-email", "Add a labeled email as label:value (repeatable)")
+	fs.Var(&addPhones, "add-phone", "Add a labeled phone as label:value (repeatable)")
+	stdin := fs.Bool("stdin", false, "Read the contact as a JSON object from stdin")
 
 	return &Command{
 		Name:        "new",
-		Usage:       "apeople new \"Name\" [options]",
+		Usage:       "apeople new \"Name\" [options] | apeople new --stdin",
 		Description: "Create a new contact",
 		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
+			if *stdin {
+				contact, err := readContactJSON(os.Stdin)
+				if err != nil {
+					return err
+				}
+				if contact.RelationshipType == "" {
+					contact.RelationshipType = model.RelationshipType(*relType)
+				}
+				if contact.ContactStyle == "" {
+					contact.ContactStyle = model.ContactStyle(*style)
+				}
+				if contact.State == "" {
+					contact.State = *state
+				}
+				if err := validateContact(contact); err != nil {
+					return err
+				}
+				warnFrequencyRange(contact.CustomFrequencyDays)
+				if err := checkStateChange("", contact.State, *force, false); err != nil {
+					return err
+				}
+				if err := createContact(cfg.ContactsDirectory, &contact); err != nil {
+					return err
+				}
+				return printCreated(contact)
+			}
+
 			if len(args) == 0 {
 				return fmt.Errorf("usage: apeople new \"Name\" [options]")
 			}
 
 			name := strings.Join(args, " ")
+			if err := checkRelationshipType(*relType); err != nil {
+				return err
+			}
+			if err := checkStateChange("", *state, *force, false); err != nil {
+				return err
+			}
+			if *frequency < 0 {
+				return fmt.Errorf("--frequency must not be negative")
+			}
+			warnFrequencyRange(*frequency)
 
 			// Create contact with acore identity
 			contact := parser.NewContact(name, cfg.ContactsDirectory)
 
 			// Build tags
-			contactTags := []string{"contact"}
+			contactTags := []string{parser.ContactTag()}
 			if *tags != "" {
 				for _, t := range strings.Split(*tags, ",") {
 					t = strings.TrimSpace(t)
-					if t != "" && t != "contact" {
+					if t != "" && t != parser.ContactTag() {
 						contactTags = append(contactTags, t)
 					}
 				}
@@ -369,42 +647,204 @@ func newCommand(cfg *config.Config) *Command {
 			contact.Company = *company
 			contact.Role = *role
 			contact.Location = *location
-
-			// Get index_id
-			counter, err := acore.NewIndexCounter(acore.NewLocalStore(cfg.ContactsDirectory), "apeople")
-			if err != nil {
-				return fmt.Errorf("failed to get ID counter: %w", err)
+			contact.Avatar = resolveAvatar(*avatar)
+			contact.CustomFrequencyDays = *frequency
+			for _, arg := range addEmails {
+				contact.AddEmail(splitLabeled(arg))
 			}
-			id, err := counter.Next()
-			if err != nil {
-				return fmt.Errorf("failed to get next ID: %w", err)
+			for _, arg := range addPhones {
+				contact.AddPhone(splitLabeled(arg))
 			}
-			contact.IndexID = id
-
-			// Generate file path
-			contact.FilePath = parser.GenerateFilePath(cfg.ContactsDirectory, contact)
 
-			if err := parser.SaveContactFile(contact); err != nil {
-				return fmt.Errorf("failed to create contact: %w", err)
+			if err := createContact(cfg.ContactsDirectory, &contact); err != nil {
+				return err
 			}
+			return printCreated(contact)
+		},
+	}
+}
 
-			if globalFlags.JSON {
-				saved, err := parser.ParseContactFile(contact.FilePath)
-				if err != nil {
-					return fmt.Errorf("created but failed to reload: %w", err)
-				}
-				saved.IndexID = contact.IndexID
-				data, _ := json.MarshalIndent(saved, "", "  ")
-				fmt.Println(string(data))
-				return nil
-			}
+// frequencySource says where a contact's frequency comes from: "custom" or
+// "<type> default".
+func frequencySource(c *model.Contact) string {
+	if c.CustomFrequencyDays > 0 {
+		return "custom"
+	}
+	return fmt.Sprintf("%s default", c.RelationshipType)
+}
 
-			if !globalFlags.Quiet {
-				fmt.Printf("Created contact #%d: %s\n", contact.IndexID, name)
-			}
-			return nil
-		},
+// flagWasSet reports whether the named flag was given on the command line, for
+// flags whose zero value is meaningful.
+func flagWasSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// warnFrequencyRange warns on stderr when a custom frequency lies outside
+// min_frequency_days/max_frequency_days and will be clamped.
+func warnFrequencyRange(days int) {
+	policy := model.CurrentPolicy()
+	if days <= 0 || policy.FrequencyInRange(days) {
+		return
+	}
+	bound := policy.MaxFrequencyDays
+	if policy.MinFrequencyDays > 0 && days < policy.MinFrequencyDays {
+		bound = policy.MinFrequencyDays
+	}
+	fmt.Fprintf(os.Stderr, "Warning: frequency of %d days is outside the configured range; %d days will be used\n", days, bound)
+}
+
+// resolveAvatar prepares an --avatar value for storage. URLs pass through
+// untouched; local paths are made absolute so they resolve from anywhere, with
+// a warning (not an error) when the file doesn't exist.
+func resolveAvatar(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || isAvatarURL(value) {
+		return value
+	}
+	if strings.HasPrefix(value, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			value = filepath.Join(home, value[2:])
+		}
+	}
+	if abs, err := filepath.Abs(value); err == nil {
+		value = abs
+	}
+	if _, err := os.Stat(value); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: avatar %s does not exist\n", value)
 	}
+	return value
+}
+
+// isAvatarURL reports whether an avatar is a URL rather than a local path.
+func isAvatarURL(value string) bool {
+	lower := strings.ToLower(value)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "data:")
+}
+
+// createContact assigns the next index_id and a file path to a new contact,
+// seeds its body from new_contact_template and saves it.
+func createContact(dir string, contact *model.Contact) error {
+	if err := parser.ApplyNewContactTemplate(contact); err != nil {
+		return err
+	}
+	id, err := parser.NextIndexID(dir)
+	if err != nil {
+		return err
+	}
+	contact.IndexID = id
+
+	contact.FilePath = parser.GenerateFilePath(dir, *contact)
+
+	if err := parser.SaveContactFile(*contact); err != nil {
+		return fmt.Errorf("failed to create contact: %w", err)
+	}
+	return nil
+}
+
+// printCreated reports a newly created contact.
+func printCreated(contact model.Contact) error {
+	if globalFlags.JSON {
+		saved, err := parser.ParseContactFile(contact.FilePath)
+		if err != nil {
+			return fmt.Errorf("created but failed to reload: %w", err)
+		}
+		saved.IndexID = contact.IndexID
+		data, _ := marshalJSON(saved)
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if !globalFlags.Quiet {
+		fmt.Printf("Created contact #%d: %s\n", contact.IndexID, contact.Title)
+	}
+	return nil
+}
+
+// readContactJSON decodes a single contact JSON object (as produced by show --json)
+// into a fresh contact with a new identity. Stored identity fields (id, index_id,
+// file_path, created, modified) in the input are ignored; a "content" key seeds the body.
+func readContactJSON(r io.Reader) (model.Contact, error) {
+	var in struct {
+		model.Contact
+		Content string `json:"content"`
+	}
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&in); err != nil {
+		return model.Contact{}, fmt.Errorf("invalid contact JSON: %w", err)
+	}
+	return fromImported(in.Contact, in.Content), nil
+}
+
+// fromImported builds a new contact from decoded fields, giving it a fresh
+// acore identity and normalizing tags and relation slices.
+func fromImported(src model.Contact, content string) model.Contact {
+	fresh := parser.NewContact(strings.TrimSpace(src.Title), "")
+
+	contact := src
+	contact.ID = fresh.ID
+	contact.Title = fresh.Title
+	contact.Type = fresh.Type
+	contact.IndexID = 0
+	contact.FilePath = ""
+	contact.Created = fresh.Created
+	contact.Modified = fresh.Modified
+	contact.Content = content
+
+	tags := []string{parser.ContactTag()}
+	for _, t := range contact.Tags {
+		t = strings.TrimSpace(t)
+		if t != "" && t != parser.ContactTag() {
+			acore.AddRelation(&tags, t)
+		}
+	}
+	contact.Tags = tags
+	contact.EnsureSlices()
+	return contact
+}
+
+// checkRelationshipType rejects a --type that is neither built in nor
+// configured, listing the valid ones.
+func checkRelationshipType(t string) error {
+	if model.ValidRelationshipType(t) {
+		return nil
+	}
+	var names []string
+	for _, rt := range model.AllRelationshipTypes() {
+		names = append(names, string(rt))
+	}
+	return fmt.Errorf("invalid --type %q (use %s)", t, strings.Join(names, ", "))
+}
+
+// checkContactStyle rejects a --style that isn't a known contact style.
+func checkContactStyle(s string) error {
+	if model.ValidContactStyle(s) {
+		return nil
+	}
+	var names []string
+	for _, cs := range model.ContactStyles {
+		names = append(names, string(cs))
+	}
+	return fmt.Errorf("invalid --style %q (use %s)", s, strings.Join(names, ", "))
+}
+
+// validateContact checks the fields a new contact must have before it is saved.
+func validateContact(c model.Contact) error {
+	if c.Title == "" {
+		return fmt.Errorf("contact title is required")
+	}
+	if c.RelationshipType != "" && !model.ValidRelationshipType(string(c.RelationshipType)) {
+		return fmt.Errorf("invalid relationship_type %q for %s", c.RelationshipType, c.Title)
+	}
+	if c.ContactStyle != "" && !model.ValidContactStyle(string(c.ContactStyle)) {
+		return fmt.Errorf("invalid contact_style %q for %s", c.ContactStyle, c.Title)
+	}
+	return nil
 }
 
 func updateCommand(cfg *config.Config) *Command {
@@ -419,8 +859,20 @@ func updateCommand(cfg *config.Config) *Command {
 	tags := fs.String("tags", "", "Set tags (comma-separated, replaces existing non-contact tags)")
 	addTag := fs.String("add-tag", "", "Add a tag (preserves existing tags)")
 	removeTag := fs.String("remove-tag", "", "Remove a tag")
+	addAlias := fs.String("add-alias", "", "Add an alias the contact can be looked up by")
+	removeAlias := fs.String("remove-alias", "", "Remove an alias")
 	state := fs.String("state", "", "Update state")
+	force := fs.Bool("force", false, "Accept an unknown state")
+	strict := fs.Bool("strict-transitions", false, "Reject disallowed state transitions (e.g. archived -> ping)")
 	location := fs.String("location", "", "Update location")
+	avatar := fs.String("avatar", "", "Set the photo: a local image path or a URL ('none' to clear)")
+	frequency := fs.Int("frequency", 0, "Set a custom frequency in days (0 reverts to the type default)")
+	lead := fs.String("lead", "", "Days before due to flag the contact for attention ('none' reverts to the global window)")
+	var addEmails, addPhones stringList
+	fs.Var(&addEmails, "add-email", "Add a labeled email as label:value (repeatable)")
+	fs.Var(&addPhones, "add-phone", "Add a labeled phone as label:value (repeatable)")
+	var clearFields stringList
+	fs.Var(&clearFields, "clear", "Empty a field, e.g. company, email, location (repeatable)")
 
 	planFor := fs.String("plan-for", "", "Set planned_for date (natural language, YYYY-MM-DD, or 'none' to clear)")
 
@@ -456,39 +908,94 @@ func updateCommand(cfg *config.Config) *Command {
 				return fmt.Errorf("contact not found: %s", args[0])
 			}
 
-			// Apply updates
-			if *name != "" {
+			// Clear first, so e.g. --clear email --email x sets a fresh value
+			for _, arg := range clearFields {
+				for _, field := range strings.Split(arg, ",") {
+					field = strings.ToLower(strings.TrimSpace(field))
+					if !contact.ClearField(field) {
+						return fmt.Errorf("unknown --clear field %q (use %s)", field, strings.Join(model.ClearableFieldNames(), ", "))
+					}
+				}
+			}
+
+			// Apply the flags that were given; an empty value clears the field
+			set := func(name string) bool { return flagWasSet(fs, name) }
+			if set("name") {
+				if strings.TrimSpace(*name) == "" {
+					return fmt.Errorf("--name must not be empty")
+				}
 				contact.Title = *name
 			}
-			if *relType != "" {
+			if set("type") {
+				if err := checkRelationshipType(*relType); err != nil {
+					return err
+				}
 				contact.RelationshipType = model.RelationshipType(*relType)
 			}
-			if *style != "" {
+			if set("style") {
+				if *style != "" {
+					if err := checkContactStyle(*style); err != nil {
+						return err
+					}
+				}
 				contact.ContactStyle = model.ContactStyle(*style)
 			}
-			if *email != "" {
+			if set("email") {
 				contact.Email = *email
 			}
-			if *phone != "" {
+			if set("phone") {
 				contact.Phone = *phone
 			}
-			if *company != "" {
+			for _, arg := range addEmails {
+				contact.AddEmail(splitLabeled(arg))
+			}
+			for _, arg := range addPhones {
+				contact.AddPhone(splitLabeled(arg))
+			}
+			if set("company") {
 				contact.Company = *company
 			}
-			if *role != "" {
+			if set("role") {
 				contact.Role = *role
 			}
-			if *location != "" {
+			if set("location") {
 				contact.Location = *location
 			}
-			if *state != "" {
+			if set("avatar") {
+				if *avatar == "" || strings.ToLower(*avatar) == "none" {
+					contact.Avatar = ""
+				} else {
+					contact.Avatar = resolveAvatar(*avatar)
+				}
+			}
+			if set("frequency") {
+				if *frequency < 0 {
+					return fmt.Errorf("--frequency must not be negative")
+				}
+				warnFrequencyRange(*frequency)
+				contact.CustomFrequencyDays = *frequency
+			}
+			if set("lead") && (*lead == "" || strings.ToLower(*lead) == "none") {
+				contact.ReminderLeadDays = nil
+			} else if set("lead") {
+				days, err := strconv.Atoi(*lead)
+				if err != nil || days < 0 {
+					return fmt.Errorf("invalid --lead %q (use a number of days or 'none')", *lead)
+				}
+				contact.ReminderLeadDays = &days
+			}
+			oldState := contact.State
+			if set("state") {
+				if err := checkStateChange(oldState, *state, *force, *strict); err != nil {
+					return err
+				}
 				contact.State = *state
 			}
-			if *tags != "" {
-				contactTags := []string{"contact"}
+			if set("tags") {
+				contactTags := []string{parser.ContactTag()}
 				for _, t := range strings.Split(*tags, ",") {
 					t = strings.TrimSpace(t)
-					if t != "" && t != "contact" {
+					if t != "" && t != parser.ContactTag() {
 						contactTags = append(contactTags, t)
 					}
 				}
@@ -496,19 +1003,28 @@ func updateCommand(cfg *config.Config) *Command {
 			}
 			if *addTag != "" {
 				tag := strings.TrimSpace(*addTag)
-				if tag != "" && tag != "contact" {
+				if tag != "" && tag != parser.ContactTag() {
 					acore.AddRelation(&contact.Tags, tag)
 				}
 			}
 			if *removeTag != "" {
 				tag := strings.TrimSpace(*removeTag)
-				if tag != "contact" {
+				if tag != parser.ContactTag() {
 					acore.RemoveRelation(&contact.Tags, tag)
 				}
 			}
+			if alias := strings.TrimSpace(*addAlias); alias != "" {
+				if other := parser.FindContactByID(contacts, alias); other != nil && other.ID != contact.ID {
+					return fmt.Errorf("%q already refers to #%d %s", alias, other.IndexID, other.Title)
+				}
+				acore.AddRelation(&contact.Aliases, alias)
+			}
+			if alias := strings.TrimSpace(*removeAlias); alias != "" {
+				acore.RemoveRelation(&contact.Aliases, alias)
+			}
 
-			if *planFor != "" {
-				if strings.ToLower(*planFor) == "none" {
+			if set("plan-for") {
+				if *planFor == "" || strings.ToLower(*planFor) == "none" {
 					contact.PlannedFor = ""
 				} else {
 					parsed, err := acore.ParseNaturalDate(*planFor)
@@ -528,6 +1044,9 @@ func updateCommand(cfg *config.Config) *Command {
 				acore.RemoveRelation(&contact.RelatedPeople, *removePerson)
 				acore.UnsyncRelation(contact.Type, contact.ID, *removePerson)
 			}
+			if *addPerson != "" || *removePerson != "" {
+				parser.RefreshRelatedLabels(contact, contacts)
+			}
 			if *addTask != "" {
 				acore.AddRelation(&contact.RelatedTasks, *addTask)
 				acore.SyncRelation(contact.Type, contact.ID, *addTask)
@@ -549,19 +1068,25 @@ func updateCommand(cfg *config.Config) *Command {
 				return fmt.Errorf("failed to update contact: %w", err)
 			}
 
+			taskCreated := createStateTask(cfg, *contact, oldState)
+
 			if globalFlags.JSON {
 				saved, err := parser.ParseContactFile(contact.FilePath)
 				if err != nil {
 					return fmt.Errorf("updated but failed to reload: %w", err)
 				}
 				saved.IndexID = contact.IndexID
-				data, _ := json.MarshalIndent(saved, "", "  ")
+				data, _ := marshalJSON(saved)
 				fmt.Println(string(data))
 				return nil
 			}
 
 			if !globalFlags.Quiet {
-				fmt.Printf("Updated contact #%d: %s\n", contact.IndexID, contact.Title)
+				msg := fmt.Sprintf("Updated contact #%d: %s", contact.IndexID, contact.Title)
+				if taskCreated {
+					msg += " [task created]"
+				}
+				fmt.Println(msg)
 			}
 			return nil
 		},
@@ -572,14 +1097,24 @@ func logCommand(cfg *config.Config) *Command {
 	fs := flag.NewFlagSet("log", flag.ContinueOnError)
 	interaction := fs.String("interaction", "", "Interaction type (required: email, call, text, meeting, social, bump, note)")
 	state := fs.String("state", "", "Set new state after interaction")
+	force := fs.Bool("force", false, "Accept an unknown state or a future --date")
+	strict := fs.Bool("strict-transitions", false, "Reject disallowed state transitions (e.g. archived -> ping)")
 	note := fs.String("note", "", "Add a note about the interaction")
+	date := fs.String("date", "", "Date of the interaction (YYYY-MM-DD, default today)")
+	noteFile := fs.String("note-file", "", "Read a multi-line note from a file (- for stdin), added as an indented block")
+	duration := fs.Int("duration", 0, "Length of a call, meeting or social interaction in minutes")
+	batch := fs.String("batch", "", "Log one interaction per line of this file (- for stdin), each as id,interaction[,note]")
+	allowDup := fs.Bool("allow-duplicate", false, "Log even if an identical entry (date, type, duration, note) is already in the log")
 
 	return &Command{
 		Name:        "log",
-		Usage:       "apeople log <id> --interaction <type> [options]",
+		Usage:       "apeople log <id> --interaction <type> [--note text] [--note-file path] [--date YYYY-MM-DD] [--duration minutes] [options]\n       apeople log --batch <file|-> [--date YYYY-MM-DD]",
 		Description: "Log an interaction with a contact",
 		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
+			if *batch != "" {
+				return logBatch(cfg, *batch, *date, *force, *allowDup)
+			}
 			if len(args) == 0 {
 				return fmt.Errorf("usage: apeople log <id> --interaction <type>")
 			}
@@ -601,32 +1136,67 @@ func logCommand(cfg *config.Config) *Command {
 				return fmt.Errorf("contact not found: %s", args[0])
 			}
 
-			now := time.Now()
-			contact.LastContacted = &now
-			contact.LastInteractionType = *interaction
+			when, err := interactionDate(*date, *force)
+			if err != nil {
+				return err
+			}
 
+			oldState := contact.State
 			if *state != "" {
-				contact.State = *state
+				if err := checkStateChange(oldState, *state, *force, *strict); err != nil {
+					return err
+				}
 			}
 
 			// Build interaction log entry
-			logEntry := fmt.Sprintf("- **%s** (%s)", now.Format("2006-01-02"), *interaction)
-			if *note != "" {
-				logEntry += fmt.Sprintf(" - %s", *note)
+			kind := *interaction
+			if *duration < 0 {
+				return fmt.Errorf("--duration must be a positive number of minutes")
+			}
+			if *duration > 0 {
+				if model.InteractionType(*interaction).HasDuration() {
+					kind += fmt.Sprintf(", %dm", *duration)
+				} else {
+					fmt.Fprintf(os.Stderr, "Warning: --duration ignored for %s interactions\n", *interaction)
+				}
+			}
+			block := ""
+			if *noteFile != "" {
+				if block, err = readNoteFile(*noteFile); err != nil {
+					return err
+				}
 			}
-			contact.Content = parser.AppendInteractionLog(contact.Content, logEntry)
+
+			// A retried log of the same interaction changes nothing
+			if !*allowDup && hasLogEntry(contact, when, kind, *note, block) {
+				if globalFlags.JSON {
+					fmt.Fprintf(os.Stderr, "Already logged %s with %s on %s; nothing changed\n", *interaction, contact.Title, when.Format("2006-01-02"))
+					data, _ := marshalJSON(contact)
+					fmt.Println(string(data))
+				} else if !globalFlags.Quiet {
+					fmt.Printf("Already logged %s with %s (#%d) on %s; nothing changed\n", *interaction, contact.Title, contact.IndexID, when.Format("2006-01-02"))
+				}
+				return nil
+			}
+
+			if *state != "" {
+				contact.State = *state
+			}
+			recordInteraction(contact, when, *interaction, kind, *note, block)
 
 			if err := parser.SaveContactFile(*contact); err != nil {
 				return fmt.Errorf("failed to log interaction: %w", err)
 			}
 
+			taskCreated := createStateTask(cfg, *contact, oldState)
+
 			if globalFlags.JSON {
 				saved, err := parser.ParseContactFile(contact.FilePath)
 				if err != nil {
 					return fmt.Errorf("logged but failed to reload: %w", err)
 				}
 				saved.IndexID = contact.IndexID
-				data, _ := json.MarshalIndent(saved, "", "  ")
+				data, _ := marshalJSON(saved)
 				fmt.Println(string(data))
 				return nil
 			}
@@ -636,6 +1206,9 @@ func logCommand(cfg *config.Config) *Command {
 				if *state != "" {
 					msg += fmt.Sprintf(" [state -> %s]", *state)
 				}
+				if taskCreated {
+					msg += " [task created]"
+				}
 				fmt.Println(msg)
 			}
 			return nil
@@ -643,11 +1216,156 @@ func logCommand(cfg *config.Config) *Command {
 	}
 }
 
+// timesText spells out a count of times: "once", "twice", "12 times".
+func timesText(n int) string {
+	switch n {
+	case 1:
+		return "once"
+	case 2:
+		return "twice"
+	}
+	return fmt.Sprintf("%d times", n)
+}
+
+// interactionDate parses a --date value (today when empty), refusing future
+// dates unless force is set.
+func interactionDate(date string, force bool) (time.Time, error) {
+	now := time.Now()
+	if date == "" {
+		return now, nil
+	}
+	when, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --date %q (use YYYY-MM-DD)", date)
+	}
+	if when.After(now) && !force {
+		return time.Time{}, fmt.Errorf("--date %s is in the future (use --force to allow)", date)
+	}
+	return when, nil
+}
+
+// recordInteraction adds a log entry to the contact and moves last_contacted
+// forward. kind is the entry's parenthesized part (the type, possibly with a
+// duration); block is an indented multi-line note from readNoteFile.
+func recordInteraction(contact *model.Contact, when time.Time, interaction, kind, note, block string) {
+	// A back-filled interaction older than the last contact only adds history
+	if contact.LastContacted == nil || !when.Before(*contact.LastContacted) {
+		contact.LastContacted = &when
+		contact.LastInteractionType = interaction
+	}
+
+	logEntry := parser.InteractionLogEntry(when, kind, note)
+	contact.Content = parser.InsertInteractionLog(contact.Content, when, logEntry+block)
+}
+
+// hasLogEntry reports whether the contact's interaction log already holds an
+// entry identical to the one recordInteraction would add: same date, type,
+// duration, note and note block.
+func hasLogEntry(contact *model.Contact, when time.Time, kind, note, block string) bool {
+	entry := parser.InteractionLogEntry(when, kind, note) + block
+	candidate := parser.ParseInteractionLog(parser.InsertInteractionLog("", when, entry))
+	if len(candidate) != 1 {
+		return false
+	}
+	for _, in := range parser.ParseInteractionLog(contact.Content) {
+		if in == candidate[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// relatedPerson is a related_people ULID resolved to a loaded contact.
+type relatedPerson struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	IndexID int    `json:"index_id"`
+}
+
+// resolveRelatedPeople looks up each of the contact's related_people among
+// contacts. ULIDs that aren't contacts (e.g. tasks or ideas linked by mistake,
+// or deleted contacts) are returned separately, in order.
+func resolveRelatedPeople(contact *model.Contact, contacts []model.Contact) (resolved []relatedPerson, unresolved []string) {
+	resolved = []relatedPerson{}
+	for _, id := range contact.RelatedPeople {
+		if p := parser.FindContactByID(contacts, id); p != nil {
+			resolved = append(resolved, relatedPerson{ID: p.ID, Title: p.Title, IndexID: p.IndexID})
+		} else {
+			unresolved = append(unresolved, id)
+		}
+	}
+	return resolved, unresolved
+}
+
+// formatMinutes renders a duration in minutes as e.g. "45m" or "2h 15m".
+func formatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
+
+// readNoteFile reads a note body and formats it as a block indented under a
+// log entry, preserving line breaks. Returns "" for an empty file.
+func readNoteFile(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read note file: %w", err)
+	}
+
+	return parser.IndentNote(string(data)), nil
+}
+
+// checkStateChange rejects unknown states unless force is set. With strict it
+// also rejects transitions that model.ValidTransition disallows.
+func checkStateChange(from, to string, force, strict bool) error {
+	if !force && !model.ValidState(to) {
+		var names []string
+		for _, st := range model.ContactStates {
+			names = append(names, string(st))
+		}
+		return fmt.Errorf("unknown state %q (valid: %s; use --force to keep it)", to, strings.Join(names, ", "))
+	}
+	if strict && !model.ValidTransition(from, to) {
+		return fmt.Errorf("cannot change state from %s to %s (set it to ok first)", from, to)
+	}
+	return nil
+}
+
+// createStateTask creates a follow-up task when the contact's state changed to
+// one of the configured task states. A task failure is reported as a warning
+// since the contact itself was already saved.
+func createStateTask(cfg *config.Config, contact model.Contact, oldState string) bool {
+	if contact.State == oldState {
+		return false
+	}
+	created, err := tasks.CreateForContact(cfg, contact, contact.State)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return created
+}
+
 func bumpCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("bump", flag.ContinueOnError)
+	note := fs.String("note", "", "Why you reviewed without contacting; added to the interaction log")
+	date := fs.String("date", "", "Date of the review (YYYY-MM-DD, default today)")
+	force := fs.Bool("force", false, "Accept a future --date")
+
 	return &Command{
 		Name:        "bump",
-		Usage:       "apeople bump <id>",
+		Usage:       "apeople bump <id> [--note text] [--date YYYY-MM-DD]",
 		Description: "Bump a contact (review without contacting)",
+		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
 			if len(args) == 0 {
 				return fmt.Errorf("usage: apeople bump <id>")
@@ -667,10 +1385,22 @@ func bumpCommand(cfg *config.Config) *Command {
 				return fmt.Errorf("contact not found: %s", args[0])
 			}
 
-			now := time.Now()
-			contact.LastBumpDate = &now
+			when, err := interactionDate(*date, *force)
+			if err != nil {
+				return err
+			}
+			// A back-dated bump older than the last one only adds to the count
+			if contact.LastBumpDate == nil || when.After(*contact.LastBumpDate) {
+				contact.LastBumpDate = &when
+			}
 			contact.BumpCount++
 
+			// The note goes in the log only; last_contacted is untouched
+			if *note != "" {
+				logEntry := parser.InteractionLogEntry(when, string(model.InteractionBump), *note)
+				contact.Content = parser.InsertInteractionLog(contact.Content, when, logEntry)
+			}
+
 			if err := parser.SaveContactFile(*contact); err != nil {
 				return fmt.Errorf("failed to bump contact: %w", err)
 			}
@@ -681,7 +1411,7 @@ func bumpCommand(cfg *config.Config) *Command {
 					return fmt.Errorf("bumped but failed to reload: %w", err)
 				}
 				saved.IndexID = contact.IndexID
-				data, _ := json.MarshalIndent(saved, "", "  ")
+				data, _ := marshalJSON(saved)
 				fmt.Println(string(data))
 				return nil
 			}
@@ -697,15 +1427,16 @@ func bumpCommand(cfg *config.Config) *Command {
 func deleteCommand(cfg *config.Config) *Command {
 	fs := flag.NewFlagSet("delete", flag.ContinueOnError)
 	confirm := fs.Bool("confirm", false, "Skip confirmation prompt")
+	hard := fs.Bool("hard", false, "Permanently remove the file instead of moving it to the trash")
 
 	return &Command{
 		Name:        "delete",
-		Usage:       "apeople delete <id> [--confirm]",
-		Description: "Delete a contact file",
+		Usage:       "apeople delete <id> [--confirm] [--hard]",
+		Description: "Delete a contact (moves it to the trash unless --hard)",
 		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
 			if len(args) == 0 {
-				return fmt.Errorf("usage: apeople delete <id> [--confirm]")
+				return fmt.Errorf("usage: apeople delete <id> [--confirm] [--hard]")
 			}
 
 			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
@@ -726,8 +1457,16 @@ func deleteCommand(cfg *config.Config) *Command {
 				return fmt.Errorf("use --confirm to delete contact '%s' (%s)", contact.Title, contact.FilePath)
 			}
 
-			if err := os.Remove(contact.FilePath); err != nil {
-				return fmt.Errorf("failed to delete contact: %w", err)
+			trashPath := ""
+			if *hard {
+				if err := os.Remove(contact.FilePath); err != nil {
+					return fmt.Errorf("failed to delete contact: %w", err)
+				}
+			} else {
+				trashPath, err = parser.TrashContact(*contact)
+				if err != nil {
+					return fmt.Errorf("failed to move contact to trash: %w", err)
+				}
 			}
 
 			if globalFlags.JSON {
@@ -737,13 +1476,79 @@ func deleteCommand(cfg *config.Config) *Command {
 					"title":    contact.Title,
 					"file":     contact.FilePath,
 				}
-				data, _ := json.MarshalIndent(result, "", "  ")
+				if trashPath != "" {
+					result["trash_file"] = trashPath
+				}
+				data, _ := marshalJSON(result)
 				fmt.Println(string(data))
 				return nil
 			}
 
 			if !globalFlags.Quiet {
-				fmt.Printf("Deleted %s (#%d)\n", contact.Title, contact.IndexID)
+				if *hard {
+					fmt.Printf("Deleted %s (#%d)\n", contact.Title, contact.IndexID)
+				} else {
+					fmt.Printf("Moved %s (#%d) to trash — restore with 'apeople restore-deleted %d'\n", contact.Title, contact.IndexID, contact.IndexID)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func restoreDeletedCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "restore-deleted",
+		Usage:       "apeople restore-deleted [id]",
+		Description: "Restore a contact from the trash (lists the trash without an id)",
+		Run: func(cmd *Command, args []string) error {
+			trashDir := parser.TrashDir(cfg.ContactsDirectory)
+			trashed := []model.Contact{}
+			if _, err := os.Stat(trashDir); err == nil {
+				trashed, err = parser.FindContacts(trashDir)
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(args) == 0 {
+				if globalFlags.JSON {
+					data, _ := marshalJSON(trashed)
+					fmt.Println(string(data))
+					return nil
+				}
+				if len(trashed) == 0 {
+					fmt.Println("Trash is empty.")
+					return nil
+				}
+				for _, c := range trashed {
+					fmt.Printf("%-4d %-30s deleted %s\n", c.IndexID, c.Title, formatDate(c.DeletedAt))
+				}
+				return nil
+			}
+
+			contact := parser.FindContactByID(trashed, args[0])
+			if contact == nil {
+				return fmt.Errorf("no deleted contact found: %s", args[0])
+			}
+
+			path, err := parser.RestoreContact(cfg.ContactsDirectory, *contact)
+			if err != nil {
+				return fmt.Errorf("failed to restore contact: %w", err)
+			}
+
+			if globalFlags.JSON {
+				restored, err := parser.ParseContactFile(path)
+				if err != nil {
+					return fmt.Errorf("restored but failed to reload: %w", err)
+				}
+				data, _ := marshalJSON(restored)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if !globalFlags.Quiet {
+				fmt.Printf("Restored %s (#%d)\n", contact.Title, contact.IndexID)
 			}
 			return nil
 		},
@@ -753,10 +1558,11 @@ func deleteCommand(cfg *config.Config) *Command {
 func migrateCommand(cfg *config.Config) *Command {
 	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
 	applyMap := fs.String("apply-map", "", "Apply a migration map from another app")
+	force := fs.Bool("force", false, "Re-apply mappings that were already applied")
 
 	return &Command{
 		Name:        "migrate",
-		Usage:       "apeople migrate [--apply-map <path>]",
+		Usage:       "apeople migrate [--apply-map <path> [--force]]",
 		Description: "Migrate contacts from Denote format to acore format",
 		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
@@ -767,18 +1573,58 @@ func migrateCommand(cfg *config.Config) *Command {
 					return fmt.Errorf("failed to read migration map: %w", err)
 				}
 
-				if err := acore.ApplyMappings(cfg.ContactsDirectory, migMap.Mappings); err != nil {
-					return fmt.Errorf("failed to apply mappings: %w", err)
+				// Skip mappings already applied by an earlier run so references
+				// aren't rewritten twice
+				applied, err := parser.LoadAppliedMappings(cfg.ContactsDirectory)
+				if err != nil {
+					return fmt.Errorf("failed to read applied mappings: %w", err)
+				}
+				pending := migMap.Mappings[:0:0]
+				var pendingKeys []string
+				skipped := 0
+				for _, m := range migMap.Mappings {
+					key, err := parser.MappingKey(m)
+					if err != nil {
+						return fmt.Errorf("failed to key mapping: %w", err)
+					}
+					if applied[key] && !*force {
+						skipped++
+						continue
+					}
+					pending = append(pending, m)
+					pendingKeys = append(pendingKeys, key)
+				}
+
+				if len(pending) > 0 {
+					if err := acore.ApplyMappings(cfg.ContactsDirectory, pending); err != nil {
+						return fmt.Errorf("failed to apply mappings: %w", err)
+					}
+					for _, key := range pendingKeys {
+						applied[key] = true
+					}
+					if err := parser.SaveAppliedMappings(cfg.ContactsDirectory, applied); err != nil {
+						return fmt.Errorf("applied mappings but failed to record them: %w", err)
+					}
+				}
+
+				if globalFlags.JSON {
+					data, _ := marshalJSON(map[string]interface{}{
+						"app":     migMap.App,
+						"applied": len(pending),
+						"skipped": skipped,
+					})
+					fmt.Println(string(data))
+					return nil
 				}
 
 				if !globalFlags.Quiet {
-					fmt.Printf("Applied %d mappings from %s\n", len(migMap.Mappings), migMap.App)
+					fmt.Printf("Applied %d mappings from %s (%d already applied, skipped)\n", len(pending), migMap.App, skipped)
 				}
 				return nil
 			}
 
 			// Migrate this app's files
-			migMap, err := acore.MigrateDirectory(cfg.ContactsDirectory, "contact", "apeople")
+			migMap, err := acore.MigrateDirectory(cfg.ContactsDirectory, parser.ContactTag(), "apeople")
 			if err != nil {
 				return fmt.Errorf("migration failed: %w", err)
 			}
@@ -792,9 +1638,14 @@ func migrateCommand(cfg *config.Config) *Command {
 
 			// Initialize the index counter from migrated files
 			migrateStore := acore.NewLocalStore(cfg.ContactsDirectory)
-			counter, err := acore.NewIndexCounter(migrateStore, "apeople")
+			unlock, err := parser.LockCounter(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+			counter, err := parser.IndexCounter(cfg.ContactsDirectory)
 			if err != nil {
-				return fmt.Errorf("failed to create counter: %w", err)
+				return err
 			}
 			readIndexID := func(name string) (int, error) {
 				var entity struct {
@@ -805,7 +1656,7 @@ func migrateCommand(cfg *config.Config) *Command {
 				}
 				return entity.IndexID, nil
 			}
-			if err := counter.InitFromFiles("contact", readIndexID); err != nil {
+			if err := counter.InitFromFiles(parser.ContactTag(), readIndexID); err != nil {
 				return fmt.Errorf("counter init: %w", err)
 			}
 
@@ -816,7 +1667,7 @@ func migrateCommand(cfg *config.Config) *Command {
 			}
 
 			if globalFlags.JSON {
-				data, _ := json.MarshalIndent(migMap, "", "  ")
+				data, _ := marshalJSON(migMap)
 				fmt.Println(string(data))
 				return nil
 			}
@@ -830,6 +1681,14 @@ func migrateCommand(cfg *config.Config) *Command {
 	}
 }
 
+// labeledHeading renders a field name with an optional label, e.g. "Email (work)".
+func labeledHeading(name, label string) string {
+	if label == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, label)
+}
+
 // formatDate formats an RFC 3339 timestamp string as YYYY-MM-DD for display.
 func formatDate(rfc3339 string) string {
 	t, err := time.Parse(time.RFC3339, rfc3339)
@@ -838,3 +1697,44 @@ func formatDate(rfc3339 string) string {
 	}
 	return t.Format("2006-01-02")
 }
+
+// relativeDays renders a day offset as human-friendly relative time. Positive
+// values are in the past ("3 weeks ago"), negative values in the future ("in 5 days").
+func relativeDays(days int) string {
+	future := days < 0
+	if future {
+		days = -days
+	}
+
+	var amount string
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1:
+		if future {
+			return "tomorrow"
+		}
+		return "yesterday"
+	case days < 14:
+		amount = fmt.Sprintf("%d days", days)
+	case days < 60:
+		amount = fmt.Sprintf("%d weeks", days/7)
+	case days < 730:
+		amount = fmt.Sprintf("%d months", days/30)
+	default:
+		amount = fmt.Sprintf("%d years", days/365)
+	}
+
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
+
+func formatLongDate(rfc3339 string) string {
+	t, err := time.Parse(time.RFC3339, rfc3339)
+	if err != nil {
+		return rfc3339
+	}
+	return t.Format("January 2, 2006")
+}
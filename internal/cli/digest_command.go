@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// digest is what needs attention in the coming days.
+type digest struct {
+	Date      string           `json:"date"`
+	Within    int              `json:"within_days"`
+	Overdue   []digestEntry    `json:"overdue"`
+	DueSoon   []digestEntry    `json:"due_soon"`
+	Birthdays []digestBirthday `json:"birthdays"`
+}
+
+// digestEntry is an overdue or soon-due contact.
+type digestEntry struct {
+	IndexID       int    `json:"index_id"`
+	Title         string `json:"title"`
+	FrequencyDays int    `json:"frequency_days"`
+	DaysSince     int    `json:"days_since_contact"`
+	DueDate       string `json:"due_date,omitempty"` // empty when never contacted
+	DaysUntilDue  int    `json:"days_until_due"`     // negative when overdue
+}
+
+// digestBirthday is an upcoming birthday.
+type digestBirthday struct {
+	IndexID   int    `json:"index_id"`
+	Title     string `json:"title"`
+	Date      string `json:"date"`
+	DaysUntil int    `json:"days_until"`
+	Age       int    `json:"age,omitempty"` // 0 when the year is unknown
+}
+
+func digestCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	format := fs.String("format", "text", "Output format: text, html")
+	within := fs.Int("within", 7, "Look this many days ahead for due contacts and birthdays")
+	output := fs.String("output", "", "Write the digest to this file instead of stdout")
+
+	return &Command{
+		Name:        "digest",
+		Usage:       "apeople digest [--within days] [--format text|html] [--output file]",
+		Description: "Summary of overdue contacts, contacts due soon and upcoming birthdays, for email",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			if *within < 0 {
+				return fmt.Errorf("--within must not be negative")
+			}
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+			d := buildDigest(contacts, time.Now(), *within)
+
+			var text string
+			switch {
+			case globalFlags.JSON:
+				data, _ := marshalJSON(d)
+				text = string(data) + "\n"
+			case *format == "text":
+				text = renderDigestText(d)
+			case *format == "html":
+				text = renderDigestHTML(d)
+			default:
+				return fmt.Errorf("unsupported format: %s (use text or html)", *format)
+			}
+
+			if *output == "" {
+				fmt.Print(text)
+				return nil
+			}
+			if err := os.WriteFile(*output, []byte(text), 0644); err != nil {
+				return fmt.Errorf("failed to write digest: %w", err)
+			}
+			if !globalFlags.Quiet {
+				fmt.Printf("Wrote digest to %s\n", *output)
+			}
+			return nil
+		},
+	}
+}
+
+// buildDigest collects the overdue contacts (most overdue first, as in
+// neglected), the contacts that need attention or fall due within the
+// window, and the birthdays in the window. Archived contacts are left out.
+func buildDigest(contacts []model.Contact, now time.Time, within int) digest {
+	d := digest{
+		Date: now.Format("2006-01-02"), Within: within,
+		Overdue: []digestEntry{}, DueSoon: []digestEntry{}, Birthdays: []digestBirthday{},
+	}
+
+	for _, row := range overdueContacts(contacts) {
+		c := parser.FindContactByID(contacts, row.ID)
+		d.Overdue = append(d.Overdue, newDigestEntry(c))
+	}
+
+	active := model.FilterContacts(contacts, model.ContactFilter{})
+	for i := range active {
+		c := &active[i]
+		if c.IsOverdue() || c.LastContacted == nil {
+			continue
+		}
+		entry := newDigestEntry(c)
+		if entry.FrequencyDays > 0 && (c.NeedsAttention() || entry.DaysUntilDue <= within) {
+			d.DueSoon = append(d.DueSoon, entry)
+		}
+	}
+	sort.SliceStable(d.DueSoon, func(i, j int) bool { return d.DueSoon[i].DaysUntilDue < d.DueSoon[j].DaysUntilDue })
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for i := range active {
+		next, age, ok := active[i].NextBirthday(now)
+		if !ok {
+			continue
+		}
+		days := int(next.Sub(today).Hours()/24 + 0.5)
+		if days > within {
+			continue
+		}
+		d.Birthdays = append(d.Birthdays, digestBirthday{
+			IndexID: active[i].IndexID, Title: active[i].Title,
+			Date: next.Format("2006-01-02"), DaysUntil: days, Age: age,
+		})
+	}
+	sort.SliceStable(d.Birthdays, func(i, j int) bool { return d.Birthdays[i].DaysUntil < d.Birthdays[j].DaysUntil })
+	return d
+}
+
+func newDigestEntry(c *model.Contact) digestEntry {
+	e := digestEntry{
+		IndexID: c.IndexID, Title: c.Title,
+		FrequencyDays: c.GetFrequencyDays(), DaysSince: c.DaysSinceContact(),
+	}
+	if c.LastContacted != nil && e.FrequencyDays > 0 {
+		e.DueDate = dueDate(c)
+		e.DaysUntilDue = e.FrequencyDays - e.DaysSince
+	}
+	return e
+}
+
+// describe says when an overdue or due-soon contact is or was due.
+func (e digestEntry) describe() string {
+	switch {
+	case e.DueDate == "":
+		return "never contacted"
+	case e.DaysUntilDue < 0:
+		return fmt.Sprintf("%d days overdue (every %d days, last %d days ago)", -e.DaysUntilDue, e.FrequencyDays, e.DaysSince)
+	case e.DaysUntilDue == 0:
+		return "due today"
+	default:
+		return fmt.Sprintf("due %s (%s)", e.DueDate, relativeDays(-e.DaysUntilDue))
+	}
+}
+
+// describe says when the birthday is and the age, if known.
+func (b digestBirthday) describe() string {
+	when := "today"
+	if b.DaysUntil > 0 {
+		when = relativeDays(-b.DaysUntil)
+	}
+	date, _ := time.Parse("2006-01-02", b.Date)
+	s := fmt.Sprintf("%s (%s)", date.Format("Mon Jan 2"), when)
+	if b.Age > 0 {
+		s += fmt.Sprintf(", turns %d", b.Age)
+	}
+	return s
+}
+
+func (d digest) empty() bool {
+	return len(d.Overdue) == 0 && len(d.DueSoon) == 0 && len(d.Birthdays) == 0
+}
+
+// renderDigestText formats the digest as plain text for an email body.
+func renderDigestText(d digest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Contacts digest for %s (next %d days)\n", d.Date, d.Within)
+	if d.empty() {
+		b.WriteString("\nNothing needs attention.\n")
+		return b.String()
+	}
+
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s (%d)\n", title, len(lines))
+		for _, l := range lines {
+			fmt.Fprintf(&b, "  - %s\n", l)
+		}
+	}
+	section("Overdue", digestLines(d.Overdue))
+	section("Due soon", digestLines(d.DueSoon))
+	var birthdays []string
+	for _, bd := range d.Birthdays {
+		birthdays = append(birthdays, fmt.Sprintf("%s (#%d): %s", bd.Title, bd.IndexID, bd.describe()))
+	}
+	section("Birthdays", birthdays)
+	return b.String()
+}
+
+func digestLines(entries []digestEntry) []string {
+	var lines []string
+	for _, e := range entries {
+		lines = append(lines, fmt.Sprintf("%s (#%d): %s", e.Title, e.IndexID, e.describe()))
+	}
+	return lines
+}
+
+// renderDigestHTML formats the digest as a small HTML document for HTML mail.
+func renderDigestHTML(d digest) string {
+	var b strings.Builder
+	esc := html.EscapeString
+
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>Contacts digest for %s</title>\n</head>\n<body>\n", esc(d.Date))
+	fmt.Fprintf(&b, "<h1>Contacts digest for %s</h1>\n<p>Next %d days</p>\n", esc(d.Date), d.Within)
+	if d.empty() {
+		b.WriteString("<p>Nothing needs attention.</p>\n")
+	}
+
+	section := func(title string, items [][2]string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "<h2>%s (%d)</h2>\n<ul>\n", esc(title), len(items))
+		for _, it := range items {
+			fmt.Fprintf(&b, "<li><strong>%s</strong>: %s</li>\n", esc(it[0]), esc(it[1]))
+		}
+		b.WriteString("</ul>\n")
+	}
+	entries := func(list []digestEntry) [][2]string {
+		var items [][2]string
+		for _, e := range list {
+			items = append(items, [2]string{fmt.Sprintf("%s (#%d)", e.Title, e.IndexID), e.describe()})
+		}
+		return items
+	}
+	section("Overdue", entries(d.Overdue))
+	section("Due soon", entries(d.DueSoon))
+	var birthdays [][2]string
+	for _, bd := range d.Birthdays {
+		birthdays = append(birthdays, [2]string{fmt.Sprintf("%s (#%d)", bd.Title, bd.IndexID), bd.describe()})
+	}
+	section("Birthdays", birthdays)
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
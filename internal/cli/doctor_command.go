@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// Doctor check outcomes.
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// doctorCheck is the result of one health check.
+type doctorCheck struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+func doctorCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "doctor",
+		Usage:       "apeople doctor",
+		Description: "Check the contacts directory for problems",
+		Run: func(cmd *Command, args []string) error {
+			checks := runDoctorChecks(cfg.ContactsDirectory)
+
+			failed := 0
+			for _, c := range checks {
+				if c.Status == checkFail {
+					failed++
+				}
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(map[string]interface{}{
+					"directory": cfg.ContactsDirectory,
+					"checks":    checks,
+					"failed":    failed,
+				})
+				fmt.Println(string(data))
+			} else {
+				for _, c := range checks {
+					fmt.Printf("[%s] %-14s %s\n", c.Status, c.Name, c.Message)
+					for _, d := range c.Details {
+						fmt.Printf("       %s\n", d)
+					}
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+			}
+			return nil
+		},
+	}
+}
+
+// runDoctorChecks runs every check against dir. Checks that need the contact
+// files are skipped when the directory is missing.
+func runDoctorChecks(dir string) []doctorCheck {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return []doctorCheck{{Name: "directory", Status: checkFail, Message: fmt.Sprintf("%s does not exist or is not a directory", dir)}}
+	}
+
+	checks := []doctorCheck{checkDirectory(dir)}
+	contacts, err := parser.FindContacts(dir)
+	if err != nil {
+		return append(checks, doctorCheck{Name: "contacts", Status: checkFail, Message: err.Error()})
+	}
+	return append(checks,
+		checkUnparseable(dir),
+		checkCounter(dir),
+		checkDuplicateIDs(contacts),
+		checkRelationships(contacts),
+	)
+}
+
+func checkDirectory(dir string) doctorCheck {
+	f, err := os.CreateTemp(dir, ".apeople-doctor-*")
+	if err != nil {
+		return doctorCheck{Name: "directory", Status: checkFail, Message: fmt.Sprintf("%s is not writable: %v", dir, err)}
+	}
+	f.Close()
+	os.Remove(f.Name())
+	return doctorCheck{Name: "directory", Status: checkPass, Message: fmt.Sprintf("%s exists and is writable", dir)}
+}
+
+func checkUnparseable(dir string) doctorCheck {
+	bad, err := parser.UnparseableContactFiles(dir)
+	if err != nil {
+		return doctorCheck{Name: "parse", Status: checkFail, Message: err.Error()}
+	}
+	if len(bad) == 0 {
+		return doctorCheck{Name: "parse", Status: checkPass, Message: "all contact files parse"}
+	}
+	var details []string
+	for name, err := range bad {
+		details = append(details, fmt.Sprintf("%s: %v", name, err))
+	}
+	sort.Strings(details)
+	return doctorCheck{Name: "parse", Status: checkWarn,
+		Message: fmt.Sprintf("%d contact files can't be parsed and are skipped", len(bad)), Details: details}
+}
+
+func checkCounter(dir string) doctorCheck {
+	next, max, err := parser.CheckIndexCounter(dir)
+	if err != nil {
+		return doctorCheck{Name: "counter", Status: checkFail, Message: err.Error()}
+	}
+	if next <= max {
+		return doctorCheck{Name: "counter", Status: checkFail,
+			Message: fmt.Sprintf("counter would assign #%d but #%d is in use; run `apeople reindex-counter`", next, max)}
+	}
+	return doctorCheck{Name: "counter", Status: checkPass, Message: fmt.Sprintf("next index_id #%d is past the highest in use (#%d)", next, max)}
+}
+
+func checkDuplicateIDs(contacts []model.Contact) doctorCheck {
+	dupes := parser.DuplicateIndexIDs(contacts)
+	if len(dupes) == 0 {
+		return doctorCheck{Name: "index IDs", Status: checkPass, Message: "no duplicate index IDs"}
+	}
+	ids := make([]int, 0, len(dupes))
+	for id := range dupes {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	var details []string
+	for _, id := range ids {
+		var titles []string
+		for _, c := range dupes[id] {
+			titles = append(titles, c.Title)
+		}
+		details = append(details, fmt.Sprintf("#%d: %s", id, strings.Join(titles, ", ")))
+	}
+	return doctorCheck{Name: "index IDs", Status: checkFail,
+		Message: fmt.Sprintf("%d index IDs are shared; run `apeople fix-ids`", len(dupes)), Details: details}
+}
+
+// checkRelationships warns about related_people links that the other contact
+// doesn't return, and links to contacts that don't exist.
+func checkRelationships(contacts []model.Contact) doctorCheck {
+	byID := map[string]*model.Contact{}
+	for i := range contacts {
+		byID[contacts[i].ID] = &contacts[i]
+	}
+
+	var details []string
+	for _, c := range contacts {
+		for _, id := range c.RelatedPeople {
+			other, ok := byID[id]
+			switch {
+			case !ok:
+				details = append(details, fmt.Sprintf("%s links to unknown contact %s", c.Title, id))
+			case !slices.Contains(other.RelatedPeople, c.ID):
+				details = append(details, fmt.Sprintf("%s links to %s, but not the other way round", c.Title, other.Title))
+			}
+		}
+	}
+	if len(details) == 0 {
+		return doctorCheck{Name: "relationships", Status: checkPass, Message: "all related_people links are mutual"}
+	}
+	return doctorCheck{Name: "relationships", Status: checkWarn,
+		Message: fmt.Sprintf("%d one-sided or dangling related_people links", len(details)), Details: details}
+}
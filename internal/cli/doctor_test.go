@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+func TestCheckCounterIsReadOnly(t *testing.T) {
+	cfg := seedUpdateContact(t) // contact #1
+	dir := cfg.ContactsDirectory
+	if _, err := parser.NextIndexID(dir); err != nil {
+		t.Fatalf("NextIndexID: %v", err)
+	}
+	before, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if check := checkCounter(dir); check.Status != checkPass {
+			t.Errorf("run %d: counter check = %s: %s, want a pass", i+1, check.Status, check.Message)
+		}
+	}
+
+	if after, _ := os.ReadDir(dir); len(after) != len(before) {
+		t.Errorf("checks changed the directory from %d to %d entries", len(before), len(after))
+	}
+
+	next, err := parser.NextIndexID(dir)
+	if err != nil {
+		t.Fatalf("NextIndexID: %v", err)
+	}
+	if next != 2 {
+		t.Errorf("counter handed out #%d after the checks, want #2 (the checks advanced it)", next)
+	}
+}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// dumpedContact is one element of a dump: the contact plus its full body.
+type dumpedContact struct {
+	model.Contact
+	Content string `json:"content"`
+}
+
+func dumpCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	output := fs.String("output", "", "Write the dump to this file instead of stdout")
+	since := fs.String("since", "", "Only contacts modified after this date (YYYY-MM-DD or RFC 3339)")
+
+	return &Command{
+		Name:        "dump",
+		Usage:       "apeople dump [--output file] [--since date]",
+		Description: "Dump every contact, including content, as one JSON array",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			var filter model.ContactFilter
+			filter.IncludeArchived = true
+			if *since != "" {
+				t, err := parseSince(*since)
+				if err != nil {
+					return err
+				}
+				filter.ModifiedSince = t
+			}
+
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = model.FilterContacts(contacts, filter)
+
+			dump := make([]dumpedContact, 0, len(contacts))
+			for _, c := range contacts {
+				dump = append(dump, dumpedContact{Contact: c, Content: c.Content})
+			}
+			data, err := marshalJSON(dump)
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+
+			if *output == "" {
+				fmt.Println(string(data))
+				return nil
+			}
+			if err := os.WriteFile(*output, append(data, '\n'), 0644); err != nil {
+				return fmt.Errorf("failed to write dump: %w", err)
+			}
+			if !globalFlags.Quiet {
+				fmt.Printf("Dumped %d contacts to %s\n", len(dump), *output)
+			}
+			return nil
+		},
+	}
+}
+
+// parseSince parses a --since value: a date (local midnight) or an RFC 3339 timestamp.
+func parseSince(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use YYYY-MM-DD or RFC 3339)", value)
+	}
+	return t, nil
+}
+
+func loadCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("load", flag.ContinueOnError)
+	overwrite := fs.Bool("overwrite", false, "Replace existing contact files with the same name or ID")
+
+	return &Command{
+		Name:        "load",
+		Usage:       "apeople load [--overwrite] <file|->",
+		Description: "Recreate contacts from a dump, preserving IDs and index IDs",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("usage: apeople load [--overwrite] <file|->")
+			}
+
+			var r io.Reader = os.Stdin
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to open dump: %w", err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			var dump []dumpedContact
+			if err := json.NewDecoder(r).Decode(&dump); err != nil {
+				return fmt.Errorf("invalid dump: %w", err)
+			}
+
+			dir := cfg.ContactsDirectory
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create contacts directory: %w", err)
+			}
+			existing, err := parser.FindContacts(dir)
+			if err != nil {
+				return err
+			}
+			existingByID := map[string]string{}
+			for _, c := range existing {
+				existingByID[c.ID] = c.FilePath
+			}
+
+			// Resolve every target path first so a conflict aborts before anything is written
+			contacts := make([]model.Contact, 0, len(dump))
+			var conflicts []string
+			for _, item := range dump {
+				c := item.Contact
+				c.Content = item.Content
+				if c.ID == "" || c.Title == "" {
+					return fmt.Errorf("dump entry is missing id or title: %q", c.Title)
+				}
+				c.EnsureSlices()
+
+				switch {
+				case existingByID[c.ID] != "":
+					c.FilePath = existingByID[c.ID]
+				case c.FilePath != "":
+					c.FilePath = filepath.Join(dir, filepath.Base(c.FilePath))
+				default:
+					c.FilePath = parser.GenerateFilePath(dir, c)
+				}
+				if _, err := os.Stat(c.FilePath); err == nil && !*overwrite {
+					conflicts = append(conflicts, filepath.Base(c.FilePath))
+				}
+				contacts = append(contacts, c)
+			}
+			if len(conflicts) > 0 {
+				return fmt.Errorf("%d contacts already exist (use --overwrite): %v", len(conflicts), conflicts)
+			}
+
+			for _, c := range contacts {
+				if err := parser.SaveContactFile(c); err != nil {
+					return fmt.Errorf("failed to write %s: %w", c.Title, err)
+				}
+			}
+
+			// Move the index counter past the loaded index IDs
+			unlock, err := parser.LockCounter(dir)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+			counter, err := parser.IndexCounter(dir)
+			if err != nil {
+				return err
+			}
+			readIndexID := func(name string) (int, error) {
+				c, err := parser.ParseContactFile(filepath.Join(dir, name))
+				if err != nil {
+					return 0, err
+				}
+				return c.IndexID, nil
+			}
+			if err := counter.InitFromFiles(parser.ContactTag(), readIndexID); err != nil {
+				return fmt.Errorf("counter init: %w", err)
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(map[string]int{"loaded": len(contacts)})
+				fmt.Println(string(data))
+				return nil
+			}
+			if !globalFlags.Quiet {
+				fmt.Printf("Loaded %d contacts into %s\n", len(contacts), dir)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// csvDialects maps --dialect names to their CSV writers.
+var csvDialects = map[string]func(w *csv.Writer, contacts []model.Contact) error{
+	"generic": writeGenericCSV,
+	"google":  writeGoogleCSV,
+	"apple":   writeAppleCSV,
+}
+
+func exportCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "csv", "Output format: csv, json, ndjson, sql, vcard")
+	dialect := fs.String("dialect", "generic", "CSV columns: generic, google (Google Contacts), apple (Apple Contacts)")
+	output := fs.String("output", "", "Write to this file instead of stdout")
+	since := fs.String("since", "", "Only contacts modified after this date (YYYY-MM-DD or RFC 3339)")
+	all := fs.Bool("all", false, "Include archived contacts")
+	anonymize := fs.Bool("anonymize", false, "Replace names, emails, phones, companies and other personal details with stable fakes")
+
+	return &Command{
+		Name:        "export",
+		Usage:       "apeople export [--format csv|json|ndjson|sql|vcard] [--dialect generic|google|apple] [--since date] [--output file] [--all] [--anonymize]",
+		Description: "Export contacts for other address books",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			filter := model.ContactFilter{IncludeArchived: *all}
+			if *since != "" {
+				t, err := parseSince(*since)
+				if err != nil {
+					return err
+				}
+				filter.ModifiedSince = t
+			}
+
+			// Validate the format before creating the output file
+			var writeDialect func(*csv.Writer, []model.Contact) error
+			switch *format {
+			case "json", "ndjson", "jsonl", "sql", "vcard", "vcf":
+			case "csv":
+				var ok bool
+				if writeDialect, ok = csvDialects[*dialect]; !ok {
+					return fmt.Errorf("unsupported dialect: %s (use generic, google or apple)", *dialect)
+				}
+			default:
+				return fmt.Errorf("unsupported format: %s (use csv, json, ndjson, sql or vcard)", *format)
+			}
+
+			var out io.Writer = os.Stdout
+			if *output != "" {
+				f, err := os.Create(*output)
+				if err != nil {
+					return fmt.Errorf("failed to create %s: %w", *output, err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			prepare := func(c model.Contact) model.Contact {
+				if *anonymize {
+					return anonymizeContact(c)
+				}
+				return c
+			}
+
+			count := 0
+			if *format == "ndjson" || *format == "jsonl" {
+				// NDJSON streams straight from the files so memory stays flat
+				enc := json.NewEncoder(out)
+				err := parser.WalkContacts(cfg.ContactsDirectory, func(c model.Contact) error {
+					if !filter.Matches(&c) {
+						return nil
+					}
+					count++
+					return enc.Encode(prepare(c))
+				})
+				if err != nil {
+					return fmt.Errorf("failed to write export: %w", err)
+				}
+			} else {
+				contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+				if err != nil {
+					return err
+				}
+				contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+				contacts = model.FilterContacts(contacts, filter)
+				count = len(contacts)
+				// Parse logs first: anonymizing drops the body they live in
+				interactions := map[string][]model.Interaction{}
+				for i := range contacts {
+					interactions[contacts[i].ID] = parser.ParseInteractionLog(contacts[i].Content)
+					contacts[i] = prepare(contacts[i])
+				}
+
+				switch {
+				case *format == "sql":
+					if err := writeSQL(out, contacts, interactions, *anonymize); err != nil {
+						return fmt.Errorf("failed to write export: %w", err)
+					}
+				case *format == "vcard" || *format == "vcf":
+					if err := writeVCard(out, contacts); err != nil {
+						return fmt.Errorf("failed to write export: %w", err)
+					}
+				case writeDialect == nil:
+					data, err := marshalJSON(contacts)
+					if err != nil {
+						return fmt.Errorf("failed to marshal JSON: %w", err)
+					}
+					if _, err := fmt.Fprintln(out, string(data)); err != nil {
+						return fmt.Errorf("failed to write export: %w", err)
+					}
+				default:
+					w := csv.NewWriter(out)
+					if err := writeDialect(w, contacts); err != nil {
+						return fmt.Errorf("failed to write export: %w", err)
+					}
+					w.Flush()
+					if err := w.Error(); err != nil {
+						return fmt.Errorf("failed to write export: %w", err)
+					}
+				}
+			}
+
+			if *output != "" && !globalFlags.Quiet {
+				fmt.Printf("Exported %d contacts to %s\n", count, *output)
+			}
+			return nil
+		},
+	}
+}
+
+// writeGenericCSV writes one row per contact with apeople's own field names.
+func writeGenericCSV(w *csv.Writer, contacts []model.Contact) error {
+	header := []string{"index_id", "id", "name", "email", "phone", "company", "role", "location",
+		"birthday", "relationship_type", "contact_style", "state", "label", "tags",
+		"last_contacted", "linkedin", "twitter", "website"}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, c := range contacts {
+		var emails, phones []string
+		for _, e := range c.AllEmails() {
+			emails = append(emails, e.Value)
+		}
+		for _, p := range c.AllPhones() {
+			phones = append(phones, p.Value)
+		}
+		lastContacted := ""
+		if c.LastContacted != nil {
+			lastContacted = c.LastContacted.Format("2006-01-02")
+		}
+		row := []string{strconv.Itoa(c.IndexID), c.ID, c.Title, strings.Join(emails, "; "), strings.Join(phones, "; "),
+			c.Company, c.Role, c.Location, c.Birthday, string(c.RelationshipType), string(c.ContactStyle), c.State,
+			c.Label, strings.Join(userTags(c), "; "), lastContacted, c.LinkedIn, c.Twitter, c.Website}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeGoogleCSV writes the column layout of a Google Contacts CSV export,
+// which Google Contacts imports without remapping. Email and phone columns
+// repeat as many times as the contact with the most of them needs.
+func writeGoogleCSV(w *csv.Writer, contacts []model.Contact) error {
+	maxEmails, maxPhones := 1, 1
+	for _, c := range contacts {
+		maxEmails = max(maxEmails, len(c.AllEmails()))
+		maxPhones = max(maxPhones, len(c.AllPhones()))
+	}
+
+	header := []string{"Name", "Given Name", "Family Name", "Nickname", "Birthday", "Notes", "Group Membership"}
+	for i := 1; i <= maxEmails; i++ {
+		header = append(header, fmt.Sprintf("E-mail %d - Type", i), fmt.Sprintf("E-mail %d - Value", i))
+	}
+	for i := 1; i <= maxPhones; i++ {
+		header = append(header, fmt.Sprintf("Phone %d - Type", i), fmt.Sprintf("Phone %d - Value", i))
+	}
+	header = append(header, "Address 1 - Formatted", "Organization 1 - Name", "Organization 1 - Title",
+		"Website 1 - Type", "Website 1 - Value", "Website 2 - Type", "Website 2 - Value", "Website 3 - Type", "Website 3 - Value")
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, c := range contacts {
+		given, family := splitName(c.Title)
+		groups := append([]string{"* myContacts"}, userTags(c)...)
+		row := []string{c.Title, given, family, c.Label, c.Birthday, parser.ContactNotes(c), strings.Join(groups, " ::: ")}
+		row = appendLabeled(row, c.AllEmails(), maxEmails)
+		row = appendLabeled(row, c.AllPhones(), maxPhones)
+		row = append(row, c.Location, c.Company, c.Role,
+			websiteType(c.Website, "Homepage"), c.Website,
+			websiteType(c.LinkedIn, "LinkedIn"), c.LinkedIn,
+			websiteType(c.Twitter, "Twitter"), c.Twitter)
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeAppleCSV writes the Outlook-style headers that Apple Contacts maps
+// automatically on CSV import. Phones go into the column matching their label.
+func writeAppleCSV(w *csv.Writer, contacts []model.Contact) error {
+	header := []string{"First Name", "Last Name", "Nickname", "Company", "Job Title",
+		"E-mail Address", "E-mail 2 Address", "E-mail 3 Address",
+		"Mobile Phone", "Home Phone", "Business Phone", "Other Phone",
+		"Home Address", "Birthday", "Web Page", "Notes"}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, c := range contacts {
+		given, family := splitName(c.Title)
+		emails := make([]string, 3)
+		for i, e := range c.AllEmails() {
+			if i < len(emails) {
+				emails[i] = e.Value
+			}
+		}
+
+		// mobile, home, business, other
+		phones := make([]string, 4)
+		for _, p := range c.AllPhones() {
+			slot := 3
+			switch strings.ToLower(p.Label) {
+			case "mobile", "cell", "":
+				slot = 0
+			case "home":
+				slot = 1
+			case "work", "business", "office":
+				slot = 2
+			}
+			if phones[slot] != "" {
+				slot = 3
+			}
+			if phones[slot] == "" {
+				phones[slot] = p.Value
+			}
+		}
+
+		row := []string{given, family, c.Label, c.Company, c.Role}
+		row = append(row, emails...)
+		row = append(row, phones...)
+		row = append(row, c.Location, c.Birthday, c.Website, parser.ContactNotes(c))
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendLabeled adds Type/Value column pairs for values, padded to n pairs.
+func appendLabeled(row []string, values []model.LabeledValue, n int) []string {
+	for i := 0; i < n; i++ {
+		if i >= len(values) {
+			row = append(row, "", "")
+			continue
+		}
+		label := capitalize(values[i].Label)
+		if label == "" {
+			label = "Other"
+		}
+		if i == 0 {
+			label = "* " + label // Google marks the primary value with an asterisk
+		}
+		row = append(row, label, values[i].Value)
+	}
+	return row
+}
+
+// websiteType returns label when url is set, so empty website columns stay blank.
+func websiteType(url, label string) string {
+	if url == "" {
+		return ""
+	}
+	return label
+}
+
+// splitName splits a display name into given and family names at the last space.
+func splitName(name string) (given, family string) {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// userTags returns the contact's tags without the marker tag.
+func userTags(c model.Contact) []string {
+	var tags []string
+	for _, t := range c.Tags {
+		if t != parser.ContactTag() {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// sqlSchema recreates the export tables, so loading a fresh export into an
+// existing database replaces the previous one instead of duplicating rows.
+const sqlSchema = `DROP TABLE IF EXISTS interactions;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS contacts;
+
+CREATE TABLE contacts (
+  id TEXT PRIMARY KEY,
+  index_id INTEGER,
+  title TEXT NOT NULL,
+  email TEXT,
+  phone TEXT,
+  company TEXT,
+  role TEXT,
+  location TEXT,
+  birthday TEXT,
+  relationship_type TEXT,
+  contact_style TEXT,
+  state TEXT,
+  label TEXT,
+  custom_frequency_days INTEGER,
+  frequency_days INTEGER,
+  last_contacted TEXT,
+  last_interaction_type TEXT,
+  bump_count INTEGER,
+  days_since_contact INTEGER,
+  overdue_status TEXT,
+  warmth INTEGER,
+  archived INTEGER NOT NULL,
+  created TEXT,
+  modified TEXT
+);
+
+CREATE TABLE tags (
+  contact_id TEXT NOT NULL REFERENCES contacts(id),
+  tag TEXT NOT NULL,
+  PRIMARY KEY (contact_id, tag)
+);
+
+CREATE TABLE interactions (
+  contact_id TEXT NOT NULL REFERENCES contacts(id),
+  date TEXT NOT NULL,
+  type TEXT NOT NULL,
+  summary TEXT,
+  details TEXT,
+  duration_minutes INTEGER
+);
+CREATE INDEX interactions_contact ON interactions(contact_id, date);
+`
+
+// writeSQL writes a SQL script (SQLite dialect) that recreates the contacts,
+// tags and interactions tables and fills them, for loading with e.g.
+// `sqlite3 contacts.db < contacts.sql`. interactions holds each contact's
+// parsed log by ID; with anonymize their free text is left out.
+func writeSQL(out io.Writer, contacts []model.Contact, interactions map[string][]model.Interaction, anonymize bool) error {
+	w := bufio.NewWriter(out)
+	fmt.Fprintln(w, "BEGIN TRANSACTION;")
+	fmt.Fprintln(w)
+	fmt.Fprint(w, sqlSchema)
+	fmt.Fprintln(w)
+
+	for _, c := range contacts {
+		lastContacted := ""
+		if c.LastContacted != nil {
+			lastContacted = c.LastContacted.Format("2006-01-02")
+		}
+		daysSince := "NULL"
+		if c.LastContacted != nil {
+			daysSince = strconv.Itoa(c.DaysSince)
+		}
+		archived := "0"
+		if c.Archived {
+			archived = "1"
+		}
+		fmt.Fprintf(w, "INSERT INTO contacts VALUES (%s);\n", strings.Join([]string{
+			sqlText(c.ID), strconv.Itoa(c.IndexID), sqlText(c.Title), sqlText(c.Email), sqlText(c.Phone),
+			sqlText(c.Company), sqlText(c.Role), sqlText(c.Location), sqlText(c.Birthday),
+			sqlText(string(c.RelationshipType)), sqlText(string(c.ContactStyle)), sqlText(c.State), sqlText(c.Label),
+			sqlInt(c.CustomFrequencyDays), sqlInt(c.GetFrequencyDays()), sqlText(lastContacted),
+			sqlText(c.LastInteractionType), strconv.Itoa(c.BumpCount), daysSince, sqlText(c.OverdueStatus),
+			strconv.Itoa(c.Warmth), archived, sqlText(c.Created), sqlText(c.Modified),
+		}, ", "))
+
+		for _, tag := range userTags(c) {
+			fmt.Fprintf(w, "INSERT OR IGNORE INTO tags VALUES (%s, %s);\n", sqlText(c.ID), sqlText(tag))
+		}
+
+		for _, in := range interactions[c.ID] {
+			summary, details := in.Summary, in.Details
+			if anonymize {
+				summary, details = "", ""
+			}
+			fmt.Fprintf(w, "INSERT INTO interactions VALUES (%s, %s, %s, %s, %s, %s);\n",
+				sqlText(c.ID), sqlText(in.Date.Format("2006-01-02")), sqlText(string(in.Type)),
+				sqlText(summary), sqlText(details), sqlInt(in.Minutes))
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "COMMIT;")
+	return w.Flush()
+}
+
+// sqlText quotes s as a SQL string literal; "" becomes NULL.
+func sqlText(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// sqlInt renders n as a SQL integer; 0 becomes NULL.
+func sqlInt(n int) string {
+	if n == 0 {
+		return "NULL"
+	}
+	return strconv.Itoa(n)
+}
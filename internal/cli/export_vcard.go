@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// writeVCard writes contacts as vCard 3.0 cards, the format phones and
+// address books import. A local avatar is embedded as PHOTO; a URL is
+// referenced. Avatars that can't be read are skipped with a warning.
+func writeVCard(out io.Writer, contacts []model.Contact) error {
+	w := bufio.NewWriter(out)
+	for _, c := range contacts {
+		given, family := splitName(c.Title)
+		vcardLine(w, "BEGIN:VCARD")
+		vcardLine(w, "VERSION:3.0")
+		vcardLine(w, "UID:"+vcardEscape(c.ID))
+		vcardLine(w, "FN:"+vcardEscape(c.Title))
+		vcardLine(w, "N:"+vcardEscape(family)+";"+vcardEscape(given)+";;;")
+		if len(c.Aliases) > 0 {
+			vcardLine(w, "NICKNAME:"+vcardJoin(c.Aliases))
+		}
+		for i, e := range c.AllEmails() {
+			vcardLine(w, "EMAIL"+vcardType("INTERNET", e.Label, i == 0)+":"+vcardEscape(e.Value))
+		}
+		for i, p := range c.AllPhones() {
+			vcardLine(w, "TEL"+vcardType("", p.Label, i == 0)+":"+vcardEscape(p.Value))
+		}
+		if c.Company != "" {
+			vcardLine(w, "ORG:"+vcardEscape(c.Company))
+		}
+		if c.Role != "" {
+			vcardLine(w, "TITLE:"+vcardEscape(c.Role))
+		}
+		if c.Location != "" {
+			vcardLine(w, "ADR:;;;"+vcardEscape(c.Location)+";;;")
+		}
+		if c.Birthday != "" {
+			// MM-DD birthdays have no year; vCard 3.0 spells that --MM-DD
+			bday := c.Birthday
+			if strings.Count(bday, "-") == 1 {
+				bday = "--" + bday
+			}
+			vcardLine(w, "BDAY:"+bday)
+		}
+		for _, url := range []string{
+			model.NormalizeWebsite(c.Website),
+			model.NormalizeLinkedIn(c.LinkedIn),
+			model.NormalizeTwitter(c.Twitter),
+		} {
+			if url != "" {
+				vcardLine(w, "URL:"+vcardEscape(url))
+			}
+		}
+		if photo := vcardPhoto(c); photo != "" {
+			vcardLine(w, photo)
+		}
+		if tags := userTags(c); len(tags) > 0 {
+			vcardLine(w, "CATEGORIES:"+vcardJoin(tags))
+		}
+		if notes := parser.ContactNotes(c); notes != "" {
+			vcardLine(w, "NOTE:"+vcardEscape(notes))
+		}
+		vcardLine(w, "END:VCARD")
+	}
+	return w.Flush()
+}
+
+// vcardPhoto returns the PHOTO property for the contact's avatar, or "".
+func vcardPhoto(c model.Contact) string {
+	if c.Avatar == "" {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(strings.ToLower(c.Avatar), "data:"):
+		// data:image/png;base64,... already carries the image
+		meta, data, ok := strings.Cut(c.Avatar[len("data:"):], ",")
+		if !ok || !strings.HasSuffix(meta, ";base64") {
+			return ""
+		}
+		mediaType, _, _ := strings.Cut(meta, ";")
+		return "PHOTO;ENCODING=b;TYPE=" + vcardImageType(mediaType) + ":" + data
+	case isAvatarURL(c.Avatar):
+		return "PHOTO;VALUE=uri:" + c.Avatar
+	}
+
+	data, err := os.ReadFile(c.Avatar)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping avatar for %s: %v\n", c.Title, err)
+		return ""
+	}
+	mediaType := mime.TypeByExtension(strings.ToLower(filepath.Ext(c.Avatar)))
+	return "PHOTO;ENCODING=b;TYPE=" + vcardImageType(mediaType) + ":" + base64.StdEncoding.EncodeToString(data)
+}
+
+// vcardImageType turns a media type like image/png into a vCard TYPE (PNG),
+// assuming JPEG when it's unknown.
+func vcardImageType(mediaType string) string {
+	sub, ok := strings.CutPrefix(mediaType, "image/")
+	if !ok || sub == "" {
+		return "JPEG"
+	}
+	return strings.ToUpper(sub)
+}
+
+// vcardType renders the TYPE parameters for an email or phone: base (e.g.
+// INTERNET), the value's label and PREF for the primary value.
+func vcardType(base, label string, primary bool) string {
+	var types []string
+	if base != "" {
+		types = append(types, base)
+	}
+	switch strings.ToLower(label) {
+	case "":
+	case "mobile":
+		types = append(types, "CELL")
+	default:
+		types = append(types, strings.ToUpper(vcardEscape(label)))
+	}
+	if primary {
+		types = append(types, "PREF")
+	}
+	if len(types) == 0 {
+		return ""
+	}
+	return ";TYPE=" + strings.Join(types, ",")
+}
+
+// vcardEscape escapes a text value per RFC 2426.
+func vcardEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", "", ",", `\,`, ";", `\;`).Replace(s)
+}
+
+// vcardJoin escapes values and joins them into a comma-separated list value.
+func vcardJoin(values []string) string {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = vcardEscape(v)
+	}
+	return strings.Join(escaped, ",")
+}
+
+// vcardLine writes one content line, folded at 75 octets with CRLF endings
+// as the spec requires (continuation lines start with a space).
+func vcardLine(w *bufio.Writer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// Don't split a UTF-8 sequence
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // the leading space counts
+	}
+	w.WriteString(line + "\r\n")
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// reassignment records a contact moved off a duplicated index_id.
+type reassignment struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	OldIndexID int    `json:"old_index_id"`
+	NewIndexID int    `json:"new_index_id,omitempty"` // unset in --dry-run
+}
+
+func fixIDsCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("fix-ids", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "Report duplicate index IDs without changing files")
+
+	return &Command{
+		Name:        "fix-ids",
+		Usage:       "apeople fix-ids [--dry-run]",
+		Description: "Give fresh index IDs to contacts that share one",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			dupes := parser.DuplicateIndexIDs(contacts)
+
+			ids := make([]int, 0, len(dupes))
+			for id := range dupes {
+				ids = append(ids, id)
+			}
+			sort.Ints(ids)
+
+			if !*dryRun && len(dupes) > 0 {
+				// Make sure the counter is past every ID in use before handing out new ones
+				if _, _, err := parser.ReindexCounter(cfg.ContactsDirectory); err != nil {
+					return err
+				}
+			}
+
+			result := []reassignment{}
+			for _, id := range ids {
+				// The oldest contact keeps the ID; later ones are reassigned
+				for _, c := range dupes[id][1:] {
+					r := reassignment{ID: c.ID, Title: c.Title, OldIndexID: id}
+					if !*dryRun {
+						next, err := parser.NextIndexID(cfg.ContactsDirectory)
+						if err != nil {
+							return err
+						}
+						c.IndexID = next
+						if err := parser.SaveContactFile(c); err != nil {
+							return fmt.Errorf("failed to save %s: %w", c.Title, err)
+						}
+						r.NewIndexID = next
+					}
+					result = append(result, r)
+				}
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(result)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if globalFlags.Quiet {
+				return nil
+			}
+			if len(result) == 0 {
+				fmt.Println("No duplicate index IDs.")
+				return nil
+			}
+			for _, r := range result {
+				if *dryRun {
+					fmt.Printf("  #%d is shared: %s would be reassigned\n", r.OldIndexID, r.Title)
+				} else {
+					fmt.Printf("  %s: #%d -> #%d\n", r.Title, r.OldIndexID, r.NewIndexID)
+				}
+			}
+			if *dryRun {
+				fmt.Printf("%d contacts have duplicate index IDs (run without --dry-run to fix)\n", len(result))
+			} else {
+				fmt.Printf("Reassigned %d contacts\n", len(result))
+			}
+			return nil
+		},
+	}
+}
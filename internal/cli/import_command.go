@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// importResult summarizes an import run.
+type importResult struct {
+	Created []importedContact `json:"created"`
+	Skipped []importedContact `json:"skipped"`
+}
+
+type importedContact struct {
+	IndexID int    `json:"index_id,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Title   string `json:"title"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func importCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "json", "Input format: json (array of contacts), mbox (log email interactions)")
+	allowDupes := fs.Bool("allow-dupes", false, "Create contacts even if their email matches an existing contact")
+	createMissing := fs.Bool("create-missing", false, "mbox: create stub contacts for unmatched addresses")
+	dryRun := fs.Bool("dry-run", false, "mbox: report what would be logged without changing files")
+	var self stringList
+	fs.Var(&self, "me", "mbox: your own address, ignored when matching (repeatable)")
+
+	return &Command{
+		Name:        "import",
+		Usage:       "apeople import [--format json|mbox] [--allow-dupes] [--me addr] [--create-missing] [--dry-run] [file]",
+		Description: "Import contacts, or email interactions from an mbox, from a file (or stdin)",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			var r io.Reader = os.Stdin
+			if len(args) > 0 && args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to open import file: %w", err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			switch *format {
+			case "json":
+				return importJSON(cfg, r, *allowDupes)
+			case "mbox":
+				return importMbox(cfg, r, mboxOptions{CreateMissing: *createMissing, Self: self, DryRun: *dryRun})
+			default:
+				return fmt.Errorf("unsupported import format: %s", *format)
+			}
+		},
+	}
+}
+
+// importJSON creates a contact for each element of a JSON array, skipping
+// contacts whose email is already in the store unless allowDupes is set.
+func importJSON(cfg *config.Config, r io.Reader, allowDupes bool) error {
+	var items []struct {
+		model.Contact
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return fmt.Errorf("invalid JSON array: %w", err)
+	}
+
+	existing, err := parser.FindContacts(cfg.ContactsDirectory)
+	if err != nil {
+		return err
+	}
+	seenEmails := map[string]bool{}
+	for _, c := range existing {
+		for _, e := range c.AllEmails() {
+			seenEmails[strings.ToLower(e.Value)] = true
+		}
+	}
+
+	result := importResult{Created: []importedContact{}, Skipped: []importedContact{}}
+	for _, item := range items {
+		contact := fromImported(item.Contact, item.Content)
+		if contact.RelationshipType == "" {
+			contact.RelationshipType = model.RelationshipType(cfg.DefaultType)
+		}
+		if contact.ContactStyle == "" {
+			contact.ContactStyle = model.ContactStyle(cfg.DefaultStyle)
+		}
+		if contact.State == "" {
+			contact.State = cfg.DefaultState
+		}
+
+		err := validateContact(contact)
+		if err == nil && !model.ValidState(contact.State) {
+			err = fmt.Errorf("unknown state %q", contact.State)
+		}
+		if err != nil {
+			result.Skipped = append(result.Skipped, importedContact{Title: contact.Title, Reason: err.Error()})
+			continue
+		}
+
+		if !allowDupes {
+			dupe := ""
+			for _, e := range contact.AllEmails() {
+				if seenEmails[strings.ToLower(e.Value)] {
+					dupe = e.Value
+					break
+				}
+			}
+			if dupe != "" {
+				result.Skipped = append(result.Skipped, importedContact{Title: contact.Title, Reason: "duplicate email " + dupe})
+				continue
+			}
+		}
+
+		warnFrequencyRange(contact.CustomFrequencyDays)
+		if err := createContact(cfg.ContactsDirectory, &contact); err != nil {
+			return err
+		}
+		for _, e := range contact.AllEmails() {
+			seenEmails[strings.ToLower(e.Value)] = true
+		}
+		result.Created = append(result.Created, importedContact{IndexID: contact.IndexID, ID: contact.ID, Title: contact.Title})
+	}
+
+	if globalFlags.JSON {
+		data, _ := marshalJSON(result)
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if !globalFlags.Quiet {
+		for _, c := range result.Created {
+			fmt.Printf("  created #%d: %s\n", c.IndexID, c.Title)
+		}
+		for _, c := range result.Skipped {
+			fmt.Printf("  skipped %s (%s)\n", c.Title, c.Reason)
+		}
+		fmt.Printf("Imported %d contacts, skipped %d\n", len(result.Created), len(result.Skipped))
+	}
+	return nil
+}
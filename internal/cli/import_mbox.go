@@ -0,0 +1,303 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// mboxImportResult summarizes an mbox import run.
+type mboxImportResult struct {
+	Messages   int               `json:"messages"`
+	Undated    int               `json:"undated"`
+	Logged     []mboxLogged      `json:"logged"`
+	Duplicates int               `json:"duplicates"` // matches on a date already logged
+	Created    []importedContact `json:"created"`
+	Unmatched  []mboxAddress     `json:"unmatched"`
+	DryRun     bool              `json:"dry_run"`
+}
+
+// mboxLogged lists the email interactions logged for one contact.
+type mboxLogged struct {
+	IndexID int      `json:"index_id,omitempty"`
+	Title   string   `json:"title"`
+	Dates   []string `json:"dates"`
+}
+
+// mboxAddress is an address that matched no contact.
+type mboxAddress struct {
+	Address  string `json:"address"`
+	Name     string `json:"name,omitempty"`
+	Messages int    `json:"messages"`
+}
+
+// mboxMessage is the part of a message the import needs.
+type mboxMessage struct {
+	Date      time.Time
+	Subject   string
+	Addresses []*mail.Address // From, To and Cc
+}
+
+// mboxOptions are the import flags that apply to mbox input.
+type mboxOptions struct {
+	CreateMissing bool
+	Self          []string // the user's own addresses, never matched or reported
+	DryRun        bool
+}
+
+// importMbox logs an email interaction for every contact whose address
+// appears in the From, To or Cc of a message, dated by the message, with the
+// subject as the note. A contact gets at most one email entry per day,
+// counting entries already in its log.
+func importMbox(cfg *config.Config, r io.Reader, opts mboxOptions) error {
+	messages, err := readMbox(r)
+	if err != nil {
+		return err
+	}
+
+	contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+	if err != nil {
+		return err
+	}
+	contacts, err = parser.AssignIndexIDs(cfg.ContactsDirectory, contacts)
+	if err != nil {
+		return err
+	}
+
+	byEmail := map[string]*model.Contact{}
+	for i := range contacts {
+		for _, e := range contacts[i].AllEmails() {
+			byEmail[strings.ToLower(e.Value)] = &contacts[i]
+		}
+	}
+	self := map[string]bool{}
+	for _, s := range opts.Self {
+		self[strings.ToLower(strings.TrimSpace(s))] = true
+	}
+
+	result := mboxImportResult{
+		Messages: len(messages), Logged: []mboxLogged{}, Created: []importedContact{},
+		Unmatched: []mboxAddress{}, DryRun: opts.DryRun,
+	}
+
+	// Matches per contact ID, and addresses without a contact
+	matched := map[string][]mboxMessage{}
+	unmatched := map[string]*mboxAddress{}
+	for _, msg := range messages {
+		if msg.Date.IsZero() {
+			result.Undated++
+			continue
+		}
+		for _, addr := range msg.Addresses {
+			key := strings.ToLower(addr.Address)
+			if self[key] {
+				continue
+			}
+			if c := byEmail[key]; c != nil {
+				matched[c.ID] = append(matched[c.ID], msg)
+				continue
+			}
+			u := unmatched[key]
+			if u == nil {
+				u = &mboxAddress{Address: addr.Address}
+				unmatched[key] = u
+			}
+			if u.Name == "" {
+				u.Name = addr.Name
+			}
+			u.Messages++
+		}
+	}
+
+	// Stub contacts for unmatched addresses, which then match like any other
+	keys := make([]string, 0, len(unmatched))
+	for k := range unmatched {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		u := unmatched[key]
+		if !opts.CreateMissing {
+			result.Unmatched = append(result.Unmatched, *u)
+			continue
+		}
+		title := u.Name
+		if title == "" {
+			title = u.Address
+		}
+		if opts.DryRun {
+			result.Created = append(result.Created, importedContact{Title: title})
+			continue
+		}
+		stub := parser.NewContact(title, cfg.ContactsDirectory)
+		stub.Tags = []string{parser.ContactTag()}
+		stub.RelationshipType = model.RelationshipType(cfg.DefaultType)
+		stub.ContactStyle = model.ContactStyle(cfg.DefaultStyle)
+		stub.State = cfg.DefaultState
+		stub.Email = u.Address
+		if err := createContact(cfg.ContactsDirectory, &stub); err != nil {
+			return err
+		}
+		result.Created = append(result.Created, importedContact{IndexID: stub.IndexID, ID: stub.ID, Title: stub.Title})
+		contacts = append(contacts, stub)
+		for _, msg := range messages {
+			for _, addr := range msg.Addresses {
+				if strings.EqualFold(addr.Address, u.Address) && !msg.Date.IsZero() {
+					matched[stub.ID] = append(matched[stub.ID], msg)
+				}
+			}
+		}
+	}
+
+	for i := range contacts {
+		contact := &contacts[i]
+		msgs := matched[contact.ID]
+		if len(msgs) == 0 {
+			continue
+		}
+		// Oldest first, so last_contacted ends on the newest message
+		sort.SliceStable(msgs, func(a, b int) bool { return msgs[a].Date.Before(msgs[b].Date) })
+
+		logged := map[string]bool{}
+		for _, in := range parser.ParseInteractionLog(contact.Content) {
+			if in.Type == model.InteractionEmail {
+				logged[in.Date.Format("2006-01-02")] = true
+			}
+		}
+		entry := mboxLogged{IndexID: contact.IndexID, Title: contact.Title, Dates: []string{}}
+		for _, msg := range msgs {
+			day := msg.Date.Format("2006-01-02")
+			if logged[day] {
+				result.Duplicates++
+				continue
+			}
+			logged[day] = true
+			entry.Dates = append(entry.Dates, day)
+			recordInteraction(contact, msg.Date, string(model.InteractionEmail), string(model.InteractionEmail), msg.Subject, "")
+		}
+		if len(entry.Dates) == 0 {
+			continue
+		}
+		if !opts.DryRun {
+			if err := parser.SaveContactFile(*contact); err != nil {
+				return fmt.Errorf("failed to save %s: %w", contact.Title, err)
+			}
+		}
+		result.Logged = append(result.Logged, entry)
+	}
+
+	if globalFlags.JSON {
+		data, _ := marshalJSON(result)
+		fmt.Println(string(data))
+		return nil
+	}
+	if globalFlags.Quiet {
+		return nil
+	}
+
+	verb := "logged"
+	if opts.DryRun {
+		verb = "would log"
+	}
+	total := 0
+	for _, l := range result.Logged {
+		fmt.Printf("  %s %d emails with %s (#%d)\n", verb, len(l.Dates), l.Title, l.IndexID)
+		total += len(l.Dates)
+	}
+	for _, c := range result.Created {
+		if opts.DryRun {
+			fmt.Printf("  would create %s\n", c.Title)
+		} else {
+			fmt.Printf("  created #%d: %s\n", c.IndexID, c.Title)
+		}
+	}
+	for _, u := range result.Unmatched {
+		name := u.Address
+		if u.Name != "" {
+			name = fmt.Sprintf("%s <%s>", u.Name, u.Address)
+		}
+		fmt.Printf("  unmatched %s (%d messages)\n", name, u.Messages)
+	}
+	fmt.Printf("Read %d messages: %s %d interactions for %d contacts, %d already logged, %d unmatched addresses\n",
+		result.Messages, verb, total, len(result.Logged), result.Duplicates, len(result.Unmatched))
+	if result.Undated > 0 {
+		fmt.Printf("Skipped %d messages without a readable Date header\n", result.Undated)
+	}
+	return nil
+}
+
+// readMbox reads the headers of each message in an mbox. Message bodies are
+// skipped, so memory use doesn't grow with attachments.
+func readMbox(r io.Reader) ([]mboxMessage, error) {
+	var messages []mboxMessage
+	var header strings.Builder
+	inHeader, started := false, false
+
+	flush := func() {
+		if !started {
+			return
+		}
+		messages = append(messages, parseMboxHeader(header.String()))
+		header.Reset()
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if strings.HasPrefix(line, "From ") {
+			// Envelope line: a new message starts
+			flush()
+			started, inHeader = true, true
+		} else if inHeader {
+			if strings.TrimRight(line, "\r\n") == "" {
+				inHeader = false
+			} else {
+				header.WriteString(line)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mbox: %w", err)
+		}
+	}
+	flush()
+
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("no messages found (is this an mbox file?)")
+	}
+	return messages, nil
+}
+
+// parseMboxHeader extracts the date, subject and addresses from a header
+// block. Unparseable parts are left empty rather than failing the import.
+func parseMboxHeader(header string) mboxMessage {
+	var msg mboxMessage
+	m, err := mail.ReadMessage(strings.NewReader(header + "\r\n"))
+	if err != nil {
+		return msg
+	}
+	if date, err := m.Header.Date(); err == nil {
+		msg.Date = date.In(time.Local)
+	}
+	dec := new(mime.WordDecoder)
+	if subject, err := dec.DecodeHeader(m.Header.Get("Subject")); err == nil {
+		msg.Subject = strings.Join(strings.Fields(subject), " ")
+	}
+	for _, field := range []string{"From", "To", "Cc"} {
+		if list, err := m.Header.AddressList(field); err == nil {
+			msg.Addresses = append(msg.Addresses, list...)
+		}
+	}
+	return msg
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// listLayout holds the widths of the flexible `list` columns.
+type listLayout struct {
+	Days    int
+	Name    int
+	Company int
+	Total   int // terminal width, 0 if unknown
+}
+
+const (
+	defaultNameWidth    = 22
+	defaultCompanyWidth = 20
+	minNameWidth        = 12
+	minCompanyWidth     = 8
+	maxNameWidth        = 40
+	maxCompanyWidth     = 32
+	listTagsReserve     = 16 // room left for the tags column
+)
+
+// newListLayout sizes the name and company columns for a terminal width,
+// splitting the space left after the fixed columns 55/45 up to a cap, after
+// which the tags column gets the rest. width 0 means unknown, which keeps the
+// classic 22/20 layout.
+func newListLayout(width, daysWidth int) listLayout {
+	l := listLayout{Days: daysWidth, Name: defaultNameWidth, Company: defaultCompanyWidth, Total: width}
+	if width <= 0 {
+		return l
+	}
+
+	// "#" 4+1, days +2, type 10+1, state 10+1, a space after name and company
+	fixed := 5 + daysWidth + 2 + 11 + 11 + 2
+	flexible := width - fixed - listTagsReserve
+	l.Name = min(maxNameWidth, max(minNameWidth, flexible*55/100))
+	l.Company = min(maxCompanyWidth, max(minCompanyWidth, flexible*45/100))
+	return l
+}
+
+// Width returns the width of the header rule: the table up to the tags
+// column plus room for a few tags, never wider than the terminal.
+func (l listLayout) Width() int {
+	w := 5 + l.Name + 1 + l.Days + 2 + 11 + 11 + l.Company + 1 + 12
+	if l.Total > 0 && w > l.Total {
+		return l.Total
+	}
+	return w
+}
+
+// terminalWidth returns the width of the terminal on stdout, or 0 when stdout
+// isn't a terminal or its size can't be read.
+func terminalWidth() int {
+	if !term.IsTerminal(os.Stdout.Fd()) {
+		return 0
+	}
+	width, _, err := term.GetSize(os.Stdout.Fd())
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// truncate shortens s to n runes, ending in "..." when cut.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(r[:n])
+	}
+	return string(r[:n-3]) + "..."
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// listSortKeys compares two contacts for list --sort and --sort2, each in
+// its natural direction: names, types and states ascending, most days since
+// contact and warmest first. Under days, never-contacted contacts follow the
+// contacted ones and scheduled contacts come last, soonest first.
+var listSortKeys = map[string]func(a, b *model.Contact) int{
+	"name": func(a, b *model.Contact) int {
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	},
+	"days": func(a, b *model.Contact) int {
+		if n := cmp.Compare(daysGroup(a), daysGroup(b)); n != 0 {
+			return n
+		}
+		return cmp.Compare(b.DaysSinceContact(), a.DaysSinceContact())
+	},
+	"type": func(a, b *model.Contact) int {
+		return cmp.Compare(a.RelationshipType, b.RelationshipType)
+	},
+	"state": func(a, b *model.Contact) int {
+		return cmp.Compare(a.State, b.State)
+	},
+	"warmth": func(a, b *model.Contact) int {
+		return cmp.Compare(b.Warmth, a.Warmth)
+	},
+}
+
+// daysGroup orders contacts for the days key: contacted ones first, then
+// those never contacted, then scheduled ones. Never and tomorrow both have
+// DaysSinceContact -1, so the days alone can't keep them apart.
+func daysGroup(c *model.Contact) int {
+	switch {
+	case c.LastContacted == nil:
+		return 1
+	case c.IsScheduled():
+		return 2
+	}
+	return 0
+}
+
+// listSortKeyNames lists the keys accepted by --sort and --sort2.
+var listSortKeyNames = []string{"name", "days", "type", "state", "warmth"}
+
+// sortContacts orders contacts by each key in turn, then by name and
+// finally by ID, so contacts that tie on every key always come out in the
+// same order. Keys must be in listSortKeys.
+func sortContacts(contacts []model.Contact, keys ...string) {
+	compare := make([]func(a, b *model.Contact) int, 0, len(keys)+1)
+	for _, k := range keys {
+		compare = append(compare, listSortKeys[k])
+	}
+	compare = append(compare, listSortKeys["name"])
+
+	slices.SortStableFunc(contacts, func(a, b model.Contact) int {
+		for _, c := range compare {
+			if n := c(&a, &b); n != 0 {
+				return n
+			}
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
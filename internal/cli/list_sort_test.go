@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+func TestSortContactsByDaysKeepsScheduledApart(t *testing.T) {
+	at := func(days int) *time.Time {
+		y, m, d := time.Now().Date()
+		when := time.Date(y, m, d-days, 12, 0, 0, 0, time.Local)
+		return &when
+	}
+	contact := func(title string, last *time.Time) model.Contact {
+		c := model.Contact{LastContacted: last}
+		c.Title = title
+		return c
+	}
+	contacts := []model.Contact{
+		contact("Next week", at(-7)),
+		contact("Never", nil),
+		contact("Tomorrow", at(-1)),
+		contact("Today", at(0)),
+		contact("Month ago", at(30)),
+		contact("Yesterday", at(1)),
+	}
+
+	sortContacts(contacts, "days")
+	var got []string
+	for _, c := range contacts {
+		got = append(got, c.Title)
+	}
+	want := []string{"Month ago", "Yesterday", "Today", "Never", "Tomorrow", "Next week"}
+	if !slices.Equal(got, want) {
+		t.Errorf("--sort days order = %q, want %q", got, want)
+	}
+}
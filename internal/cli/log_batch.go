@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// batchLogResult reports the outcome of one line of `log --batch`.
+type batchLogResult struct {
+	Line        int    `json:"line"`
+	Ref         string `json:"ref"`
+	Interaction string `json:"interaction"`
+	IndexID     int    `json:"index_id,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Duplicate   bool   `json:"duplicate,omitempty"` // already logged, so skipped
+	Error       string `json:"error,omitempty"`
+}
+
+// logBatch logs one interaction per line of path ("-" for stdin). Lines are
+// "id,interaction[,note]"; blank lines and lines starting with # are skipped.
+// A bad line is reported and the rest of the batch still runs. Lines already
+// in the contact's log are skipped unless allowDup.
+func logBatch(cfg *config.Config, path, date string, force, allowDup bool) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open batch file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	when, err := interactionDate(date, force)
+	if err != nil {
+		return err
+	}
+
+	contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+	if err != nil {
+		return err
+	}
+	contacts, err = parser.AssignIndexIDs(cfg.ContactsDirectory, contacts)
+	if err != nil {
+		return err
+	}
+
+	results := []batchLogResult{}
+	failed := 0
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, ",", 3)
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		res := batchLogResult{Line: n, Ref: fields[0]}
+		if len(fields) > 1 {
+			res.Interaction = fields[1]
+		}
+		note := ""
+		if len(fields) > 2 {
+			note = fields[2]
+		}
+
+		contact := parser.FindContactByID(contacts, res.Ref)
+		switch {
+		case len(fields) < 2 || res.Interaction == "":
+			res.Error = "expected id,interaction[,note]"
+		case !model.ValidInteractionType(res.Interaction):
+			res.Error = fmt.Sprintf("unknown interaction type %q", res.Interaction)
+		case contact == nil:
+			res.Error = fmt.Sprintf("contact not found: %s", res.Ref)
+		default:
+			res.IndexID, res.Title = contact.IndexID, contact.Title
+			if !allowDup && hasLogEntry(contact, when, res.Interaction, note, "") {
+				res.Duplicate = true
+				break
+			}
+			recordInteraction(contact, when, res.Interaction, res.Interaction, note, "")
+			if err := parser.SaveContactFile(*contact); err != nil {
+				res.Error = fmt.Sprintf("failed to save: %v", err)
+			}
+		}
+		if res.Error != "" {
+			failed++
+		}
+		results = append(results, res)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read batch file: %w", err)
+	}
+
+	if globalFlags.JSON {
+		data, _ := marshalJSON(results)
+		fmt.Println(string(data))
+	} else {
+		for _, res := range results {
+			if res.Error != "" {
+				fmt.Fprintf(os.Stderr, "line %d: %s\n", res.Line, res.Error)
+			} else if globalFlags.Quiet {
+				continue
+			} else if res.Duplicate {
+				fmt.Printf("line %d: %s with %s (#%d) already logged, skipped\n", res.Line, res.Interaction, res.Title, res.IndexID)
+			} else {
+				fmt.Printf("line %d: logged %s with %s (#%d)\n", res.Line, res.Interaction, res.Title, res.IndexID)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d lines failed", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+func logReorganizeCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "log-reorganize",
+		Usage:       "apeople log-reorganize <id>",
+		Description: "Group a contact's interaction log under per-year headings",
+		Run: func(cmd *Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("usage: apeople log-reorganize <id>")
+			}
+
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+
+			contact := parser.FindContactByID(contacts, args[0])
+			if contact == nil {
+				return fmt.Errorf("contact not found: %s", args[0])
+			}
+
+			content := parser.ReorganizeInteractionLog(contact.Content)
+			changed := content != contact.Content
+			if changed {
+				contact.Content = content
+				if err := parser.SaveContactFile(*contact); err != nil {
+					return fmt.Errorf("failed to save %s: %w", contact.Title, err)
+				}
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(map[string]interface{}{
+					"index_id": contact.IndexID,
+					"id":       contact.ID,
+					"changed":  changed,
+				})
+				fmt.Println(string(data))
+				return nil
+			}
+			if globalFlags.Quiet {
+				return nil
+			}
+			if changed {
+				fmt.Printf("Reorganized interaction log for %s (#%d)\n", contact.Title, contact.IndexID)
+			} else {
+				fmt.Printf("Interaction log for %s (#%d) is already grouped by year\n", contact.Title, contact.IndexID)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// renderContactMarkdown formats a contact as a standalone Markdown document.
+// Related people are resolved against contacts and linked to their files.
+// logLimit > 0 keeps only that many of the most recent log entries.
+func renderContactMarkdown(contact *model.Contact, contacts []model.Contact, logLimit int) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", contact.Title)
+
+	b.WriteString("| Field | Value |\n")
+	b.WriteString("| --- | --- |\n")
+	row := func(field, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "| %s | %s |\n", field, markdownCell(value))
+		}
+	}
+	for _, e := range contact.AllEmails() {
+		row(labeledHeading("Email", e.Label), e.Value)
+	}
+	for _, p := range contact.AllPhones() {
+		row(labeledHeading("Phone", p.Label), p.Value)
+	}
+	row("Company", contact.Company)
+	row("Role", contact.Role)
+	row("Location", contact.Location)
+	row("LinkedIn", markdownLink(model.NormalizeLinkedIn(contact.LinkedIn)))
+	row("Twitter", markdownLink(model.NormalizeTwitter(contact.Twitter)))
+	row("Website", markdownLink(model.NormalizeWebsite(contact.Website)))
+	row("Avatar", contact.Avatar)
+	row("Type", string(contact.RelationshipType))
+	row("Style", string(contact.ContactStyle))
+	row("State", contact.State)
+	row("Label", contact.Label)
+	if freq := contact.GetFrequencyDays(); freq > 0 {
+		row("Frequency", fmt.Sprintf("every %d days", freq))
+	}
+	if contact.LastContacted != nil {
+		last := contact.LastContacted.Format("January 2, 2006")
+		if contact.LastInteractionType != "" {
+			last += " (" + contact.LastInteractionType + ")"
+		}
+		row("Last contacted", last)
+	} else {
+		row("Last contacted", "never")
+	}
+	row("Created", formatLongDate(contact.Created))
+
+	var tagStrs []string
+	for _, t := range contact.Tags {
+		if t != parser.ContactTag() {
+			tagStrs = append(tagStrs, "`#"+t+"`")
+		}
+	}
+	if len(tagStrs) > 0 {
+		fmt.Fprintf(&b, "\n**Tags:** %s\n", strings.Join(tagStrs, " "))
+	}
+
+	if len(contact.RelatedPeople) > 0 {
+		b.WriteString("\n## Related People\n\n")
+		for i, id := range contact.RelatedPeople {
+			if related := parser.FindContactByID(contacts, id); related != nil {
+				fmt.Fprintf(&b, "- [%s](%s)\n", related.Title, filepath.Base(related.FilePath))
+			} else if i < len(contact.RelatedContactLabels) {
+				fmt.Fprintf(&b, "- %s\n", contact.RelatedContactLabels[i])
+			} else {
+				fmt.Fprintf(&b, "- %s\n", id)
+			}
+		}
+	}
+
+	// Show notes frontmatter not yet folded into the body where folding would put it
+	folded := *contact
+	parser.FoldNotes(&folded)
+	if body := strings.TrimSpace(parser.StripInteractionLog(folded.Content)); body != "" {
+		if !strings.HasPrefix(body, parser.NotesHeader()+"\n") {
+			b.WriteString("\n" + parser.NotesHeader() + "\n")
+		}
+		fmt.Fprintf(&b, "\n%s\n", body)
+	}
+
+	if interactions := parser.ParseInteractionLog(contact.Content); len(interactions) > 0 {
+		b.WriteString("\n" + parser.InteractionLogHeader() + "\n\n")
+		more := 0
+		if logLimit > 0 && len(interactions) > logLimit {
+			interactions, more = interactions[:logLimit], len(interactions)-logLimit
+		}
+		for _, in := range interactions {
+			fmt.Fprintf(&b, "- **%s** — %s", in.Date.Format("January 2, 2006"), in.Type)
+			if in.Summary != "" {
+				fmt.Fprintf(&b, ": %s", in.Summary)
+			}
+			b.WriteString("\n")
+			for _, line := range strings.Split(in.Details, "\n") {
+				if in.Details == "" {
+					break
+				}
+				if line == "" {
+					b.WriteString("\n")
+				} else {
+					b.WriteString("  " + line + "\n")
+				}
+			}
+		}
+		if more > 0 {
+			fmt.Fprintf(&b, "\n_... and %d more_\n", more)
+		}
+	}
+
+	return b.String()
+}
+
+// markdownCell escapes a value for use inside a Markdown table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// markdownLink renders a URL as a Markdown autolink; empty stays empty.
+func markdownLink(url string) string {
+	if url == "" {
+		return ""
+	}
+	return "<" + url + ">"
+}
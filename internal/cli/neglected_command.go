@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// neglectedContact is one row of the neglected report.
+type neglectedContact struct {
+	IndexID       int    `json:"index_id"`
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Type          string `json:"relationship_type"`
+	FrequencyDays int    `json:"frequency_days"`
+	DaysSince     int    `json:"days_since_contact"`
+	DaysOverdue   *int   `json:"days_overdue"` // null when never contacted
+}
+
+func neglectedCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("neglected", flag.ContinueOnError)
+	top := fs.Int("top", 10, "Show at most this many contacts (0 for all)")
+
+	return &Command{
+		Name:        "neglected",
+		Usage:       "apeople neglected [--top N]",
+		Description: "List overdue periodic contacts, most overdue first",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+
+			rows := overdueContacts(contacts)
+			if *top > 0 && len(rows) > *top {
+				rows = rows[:*top]
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(rows)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if len(rows) == 0 {
+				fmt.Println("No overdue periodic contacts.")
+				return nil
+			}
+
+			fmt.Printf("%-4s %-22s %-10s %5s %5s %8s\n", "#", "NAME", "TYPE", "FREQ", "DAYS", "OVERDUE")
+			fmt.Println(strings.Repeat("-", 60))
+			for _, r := range rows {
+				name := r.Title
+				if len(name) > 22 {
+					name = name[:19] + "..."
+				}
+				days, margin := "never", "-"
+				if r.DaysOverdue != nil {
+					days = fmt.Sprintf("%d", r.DaysSince)
+					margin = fmt.Sprintf("+%d", *r.DaysOverdue)
+				}
+				typeStr := r.Type
+				if typeStr == "" {
+					typeStr = "-"
+				}
+				fmt.Printf("%-4d %-22s %-10s %5d %5s %8s\n", r.IndexID, name, typeStr, r.FrequencyDays, days, margin)
+			}
+			return nil
+		},
+	}
+}
+
+// overdueContacts returns the overdue contacts with their overdue margin,
+// never-contacted first and then most overdue first. Only periodic contacts
+// (or those without a style) can be overdue.
+func overdueContacts(contacts []model.Contact) []neglectedContact {
+	overdue := model.FilterContacts(contacts, model.ContactFilter{Overdue: true})
+
+	rows := []neglectedContact{}
+	for _, c := range overdue {
+		row := neglectedContact{
+			IndexID:       c.IndexID,
+			ID:            c.ID,
+			Title:         c.Title,
+			Type:          string(c.RelationshipType),
+			FrequencyDays: c.GetFrequencyDays(),
+			DaysSince:     c.DaysSinceContact(),
+		}
+		if row.DaysSince >= 0 {
+			margin := row.DaysSince - row.FrequencyDays
+			row.DaysOverdue = &margin
+		}
+		rows = append(rows, row)
+	}
+
+	// Never-contacted first, then by margin
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i].DaysOverdue, rows[j].DaysOverdue
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return *a > *b
+	})
+	return rows
+}
+
+// overdueRank returns where the contact falls in the overdueContacts order
+// (1 is most urgent; 0 if it isn't overdue) and how many contacts are
+// overdue.
+func overdueRank(contacts []model.Contact, id string) (rank, total int) {
+	rows := overdueContacts(contacts)
+	for i, row := range rows {
+		if row.ID == id {
+			return i + 1, len(rows)
+		}
+	}
+	return 0, len(rows)
+}
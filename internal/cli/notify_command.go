@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// notification is one desktop notification.
+type notification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+func notifyCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("notify", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "Print the notifications instead of sending them")
+	limit := fs.Int("max", 5, "Send at most this many contact notifications, then one summary for the rest (0 for no limit)")
+
+	return &Command{
+		Name:        "notify",
+		Usage:       "apeople notify [--dry-run] [--max N]",
+		Description: "Desktop notifications for contacts overdue or due today, for login scripts and cron",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+			notes := buildNotifications(contacts, *limit)
+
+			notifier := ""
+			if !*dryRun {
+				notifier = findNotifier()
+				if notifier == "" && len(notes) > 0 {
+					fmt.Fprintln(os.Stderr, "Warning: no notifier found (notify-send or osascript); printing instead")
+				}
+			}
+
+			sent := 0
+			for _, n := range notes {
+				if notifier == "" {
+					continue
+				}
+				if err := sendNotification(notifier, n); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to send notification: %v\n", err)
+					continue
+				}
+				sent++
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(map[string]interface{}{
+					"notifier":      notifier,
+					"notifications": notes,
+					"sent":          sent,
+				})
+				fmt.Println(string(data))
+				return nil
+			}
+			if globalFlags.Quiet {
+				return nil
+			}
+			if notifier == "" {
+				for _, n := range notes {
+					fmt.Printf("%s: %s\n", n.Title, n.Body)
+				}
+				return nil
+			}
+			fmt.Printf("Sent %d of %d notifications via %s\n", sent, len(notes), notifier)
+			return nil
+		},
+	}
+}
+
+// buildNotifications returns a notification per contact that is overdue
+// (most overdue first) or due today, up to limit, and one summary naming how
+// many more there are.
+func buildNotifications(contacts []model.Contact, limit int) []notification {
+	var due []*model.Contact
+	for _, row := range overdueContacts(contacts) {
+		due = append(due, parser.FindContactByID(contacts, row.ID))
+	}
+	active := model.FilterContacts(contacts, model.ContactFilter{})
+	for i := range active {
+		c := &active[i]
+		if freq := c.GetFrequencyDays(); freq > 0 && c.LastContacted != nil && c.DaysSinceContact() == freq {
+			due = append(due, c)
+		}
+	}
+
+	notes := []notification{}
+	for i, c := range due {
+		if limit > 0 && i == limit {
+			more := fmt.Sprintf("%d more contacts are due", len(due)-limit)
+			if len(due)-limit == 1 {
+				more = "1 more contact is due"
+			}
+			notes = append(notes, notification{Title: "apeople", Body: more + "; run apeople neglected"})
+			break
+		}
+		body := "Due today"
+		switch {
+		case c.LastContacted == nil:
+			body = "Never contacted"
+		case c.IsOverdue():
+			body = fmt.Sprintf("Overdue: last contacted %d days ago (every %d days)", c.DaysSinceContact(), c.GetFrequencyDays())
+		}
+		notes = append(notes, notification{Title: fmt.Sprintf("Reach out to %s (#%d)", c.Title, c.IndexID), Body: body})
+	}
+	return notes
+}
+
+// findNotifier returns the notification command available on this system:
+// osascript on macOS, notify-send elsewhere, or "" if there is none.
+func findNotifier() string {
+	name := "notify-send"
+	if runtime.GOOS == "darwin" {
+		name = "osascript"
+	}
+	if _, err := exec.LookPath(name); err != nil {
+		return ""
+	}
+	return name
+}
+
+// sendNotification shows n with the given notifier. osascript gets the text
+// as arguments rather than in the script, so quotes need no escaping.
+func sendNotification(notifier string, n notification) error {
+	var c *exec.Cmd
+	switch notifier {
+	case "osascript":
+		c = exec.Command("osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			n.Title, n.Body)
+	default:
+		c = exec.Command(notifier, "--app-name=apeople", n.Title, n.Body)
+	}
+	if out, err := c.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", notifier, err, out)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+func pickCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("pick", flag.ContinueOnError)
+	resolve := fs.Bool("resolve", false, "Read picked lines from stdin and print each contact's ULID")
+	relType := fs.String("type", "", "Only contacts of this relationship type")
+	tag := fs.String("tag", "", "Only contacts with this tag")
+	all := fs.Bool("all", false, "Include archived contacts")
+
+	return &Command{
+		Name:        "pick",
+		Usage:       "apeople pick [--type t] [--tag t] [--all] | fzf | apeople pick --resolve",
+		Description: "Print index_id<TAB>title lines for a fuzzy finder, or resolve picked lines",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+
+			if *resolve {
+				return resolvePicked(contacts)
+			}
+
+			// index_id<TAB>title, one per line. Tabs and newlines in titles
+			// become spaces so every line splits the same way.
+			flatten := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+			filtered := model.FilterContacts(contacts, model.ContactFilter{Type: *relType, Tag: *tag, IncludeArchived: *all})
+			w := bufio.NewWriter(os.Stdout)
+			for _, c := range filtered {
+				fmt.Fprintf(w, "%d\t%s\n", c.IndexID, flatten.Replace(c.Title))
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// resolvePicked maps lines from `pick` (or bare IDs) on stdin back to
+// contacts: ULIDs one per line, or the contacts as a JSON array with --json.
+// Only the text before the first tab is looked at.
+func resolvePicked(contacts []model.Contact) error {
+	picked := []model.Contact{}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		ref, _, _ := strings.Cut(scanner.Text(), "\t")
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		c := parser.FindContactByID(contacts, ref)
+		if c == nil {
+			return fmt.Errorf("contact not found: %s", ref)
+		}
+		picked = append(picked, *c)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read selection: %w", err)
+	}
+	if len(picked) == 0 {
+		return fmt.Errorf("nothing picked")
+	}
+
+	if globalFlags.JSON {
+		data, _ := marshalJSON(picked)
+		fmt.Println(string(data))
+		return nil
+	}
+	for _, c := range picked {
+		fmt.Println(c.ID)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+func promoteCommand(cfg *config.Config) *Command {
+	return reclassifyCommand(cfg, "promote", 1)
+}
+
+func demoteCommand(cfg *config.Config) *Command {
+	return reclassifyCommand(cfg, "demote", -1)
+}
+
+// reclassifyCommand builds promote (step 1, closer) or demote (step -1) that
+// moves a contact one rung along the configured type ladder and records the
+// change in the interaction log, so the history of the relationship survives
+// the type change.
+func reclassifyCommand(cfg *config.Config, name string, step int) *Command {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	to := fs.String("to", "", "Move straight to this type on the ladder instead of one step")
+	note := fs.String("note", "", "Why the relationship changed; added to the log entry")
+
+	direction := "closer"
+	if step < 0 {
+		direction = "more distant"
+	}
+
+	return &Command{
+		Name:        name,
+		Usage:       fmt.Sprintf("apeople %s <id> [--to type] [--note text]", name),
+		Description: fmt.Sprintf("Move a contact to a %s relationship type on the type ladder", direction),
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("usage: apeople %s <id>", name)
+			}
+
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts, err = parser.AssignIndexIDs(cfg.ContactsDirectory, contacts)
+			if err != nil {
+				return err
+			}
+
+			contact := parser.FindContactByID(contacts, args[0])
+			if contact == nil {
+				return fmt.Errorf("contact not found: %s", args[0])
+			}
+
+			ladder := cfg.TypeLadder
+			from := string(contact.RelationshipType)
+			pos := slices.Index(ladder, from)
+			if pos < 0 {
+				return fmt.Errorf("%s's type %q is not on the type ladder (%s); use update --type",
+					contact.Title, from, strings.Join(ladder, " → "))
+			}
+
+			target := pos + step
+			if *to != "" {
+				target = slices.Index(ladder, *to)
+				if target < 0 {
+					return fmt.Errorf("%q is not on the type ladder (%s)", *to, strings.Join(ladder, " → "))
+				}
+				if (target-pos)*step <= 0 {
+					return fmt.Errorf("%s is not %s than %s", *to, direction, from)
+				}
+			}
+			if target < 0 || target >= len(ladder) {
+				end := "top"
+				if step < 0 {
+					end = "bottom"
+				}
+				return fmt.Errorf("%s is already at the %s of the type ladder (%s)", contact.Title, end, from)
+			}
+
+			contact.RelationshipType = model.RelationshipType(ladder[target])
+			now := time.Now()
+			kind := fmt.Sprintf("%s: %s→%s", model.InteractionReclassified, from, contact.RelationshipType)
+			contact.Content = parser.InsertInteractionLog(contact.Content, now, parser.InteractionLogEntry(now, kind, *note))
+
+			if err := parser.SaveContactFile(*contact); err != nil {
+				return fmt.Errorf("failed to %s contact: %w", name, err)
+			}
+
+			if globalFlags.JSON {
+				saved, err := parser.ParseContactFile(contact.FilePath)
+				if err != nil {
+					return fmt.Errorf("reclassified but failed to reload: %w", err)
+				}
+				saved.IndexID = contact.IndexID
+				data, _ := marshalJSON(saved)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if !globalFlags.Quiet {
+				msg := fmt.Sprintf("Reclassified %s (#%d): %s → %s", contact.Title, contact.IndexID, from, contact.RelationshipType)
+				if days := contact.GetFrequencyDays(); days > 0 {
+					msg += fmt.Sprintf(" (every %d days)", days)
+				}
+				fmt.Println(msg)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+func qrCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("qr", flag.ContinueOnError)
+	format := fs.String("format", "mecard", "Payload format: mecard (smaller) or vcard")
+	output := fs.String("output", "", "Write a PNG to this file instead of drawing in the terminal")
+	size := fs.Int("size", 256, "PNG width and height in pixels, with --output")
+	invert := fs.Bool("invert", false, "Swap dark and light, for terminals with a light background")
+
+	return &Command{
+		Name:        "qr",
+		Usage:       "apeople qr <id> [--format mecard|vcard] [--output file.png] [--size px] [--invert]",
+		Description: "Show a contact as a QR code a phone can scan into its address book",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("usage: apeople qr <id>")
+			}
+
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+			contact := parser.FindContactByID(contacts, args[0])
+			if contact == nil {
+				return fmt.Errorf("contact not found: %s", args[0])
+			}
+
+			var payload string
+			switch *format {
+			case "mecard":
+				payload = meCard(contact)
+			case "vcard", "vcf":
+				payload = qrVCard(contact)
+			default:
+				return fmt.Errorf("unsupported format: %s (use mecard or vcard)", *format)
+			}
+
+			code, err := qrcode.New(payload, qrcode.Medium)
+			if err != nil {
+				return fmt.Errorf("failed to encode QR code: %w", err)
+			}
+
+			if *output != "" {
+				if err := code.WriteFile(*size, *output); err != nil {
+					return fmt.Errorf("failed to write QR code: %w", err)
+				}
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(map[string]interface{}{
+					"index_id": contact.IndexID,
+					"title":    contact.Title,
+					"format":   *format,
+					"payload":  payload,
+					"output":   *output,
+				})
+				fmt.Println(string(data))
+				return nil
+			}
+			if *output != "" {
+				if !globalFlags.Quiet {
+					fmt.Printf("Wrote QR code for %s to %s\n", contact.Title, *output)
+				}
+				return nil
+			}
+			fmt.Print(code.ToSmallString(*invert))
+			return nil
+		},
+	}
+}
+
+// meCard encodes the contact's name, phones, emails and company as a MeCard,
+// the compact format phone cameras recognize.
+func meCard(c *model.Contact) string {
+	esc := strings.NewReplacer(`\`, `\\`, `;`, `\;`, `:`, `\:`, `,`, `\,`, `"`, `\"`).Replace
+
+	given, family := splitName(c.Title)
+	name := esc(given)
+	if family != "" {
+		name = esc(family) + "," + name
+	}
+	var b strings.Builder
+	b.WriteString("MECARD:N:" + name + ";")
+	for _, p := range c.AllPhones() {
+		b.WriteString("TEL:" + esc(p.Value) + ";")
+	}
+	for _, e := range c.AllEmails() {
+		b.WriteString("EMAIL:" + esc(e.Value) + ";")
+	}
+	if c.Company != "" {
+		// ORG isn't in the original MeCard spec, but iOS and Android read it
+		b.WriteString("ORG:" + esc(c.Company) + ";")
+	}
+	b.WriteString(";")
+	return b.String()
+}
+
+// qrVCard encodes the contact as a minimal vCard: name, phones, emails and
+// company. Unlike export it leaves out the photo and notes, which would make
+// the code too dense to scan.
+func qrVCard(c *model.Contact) string {
+	var b strings.Builder
+	w := bufio.NewWriter(&b)
+	given, family := splitName(c.Title)
+	vcardLine(w, "BEGIN:VCARD")
+	vcardLine(w, "VERSION:3.0")
+	vcardLine(w, "FN:"+vcardEscape(c.Title))
+	vcardLine(w, "N:"+vcardEscape(family)+";"+vcardEscape(given)+";;;")
+	for i, p := range c.AllPhones() {
+		vcardLine(w, "TEL"+vcardType("", p.Label, i == 0)+":"+vcardEscape(p.Value))
+	}
+	for i, e := range c.AllEmails() {
+		vcardLine(w, "EMAIL"+vcardType("INTERNET", e.Label, i == 0)+":"+vcardEscape(e.Value))
+	}
+	if c.Company != "" {
+		vcardLine(w, "ORG:"+vcardEscape(c.Company))
+	}
+	vcardLine(w, "END:VCARD")
+	w.Flush()
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+func reindexCounterCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "reindex-counter",
+		Usage:       "apeople reindex-counter",
+		Description: "Reset the index ID counter to one past the highest index_id in use",
+		Run: func(cmd *Command, args []string) error {
+			previous, max, err := parser.ReindexCounter(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			drifted := previous <= max
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(map[string]interface{}{
+					"previous_next_index_id": previous,
+					"max_index_id":           max,
+					"next_index_id":          max + 1,
+					"was_behind":             drifted,
+				})
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if drifted {
+				fmt.Fprintf(os.Stderr, "Warning: counter was at #%d but #%d is already in use; it would have assigned a duplicate ID\n", previous, max)
+			}
+			if !globalFlags.Quiet {
+				fmt.Printf("Index counter reset: next index_id is #%d (was #%d)\n", max+1, previous)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+func relationsCommand(cfg *config.Config) *Command {
+	cmd := &Command{
+		Name:        "relations",
+		Usage:       "apeople relations <subcommand>",
+		Description: "Maintain cached relationship data",
+	}
+	cmd.Subcommands = append(cmd.Subcommands, relationsRefreshCommand(cfg))
+	return cmd
+}
+
+func relationsRefreshCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "refresh",
+		Usage:       "apeople relations refresh",
+		Description: "Rebuild related contact names after renames",
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+
+			updated := []string{}
+			for i := range contacts {
+				c := &contacts[i]
+				if !parser.RefreshRelatedLabels(c, contacts) {
+					continue
+				}
+				if err := parser.SaveContactFile(*c); err != nil {
+					return fmt.Errorf("failed to save %s: %w", c.Title, err)
+				}
+				updated = append(updated, c.Title)
+			}
+
+			if globalFlags.JSON {
+				result := map[string]interface{}{
+					"updated": len(updated),
+					"titles":  updated,
+				}
+				data, _ := marshalJSON(result)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if !globalFlags.Quiet {
+				fmt.Printf("Refreshed related names on %d contacts\n", len(updated))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// reportGroup is the set of contacts sharing a relationship type.
+type reportGroup struct {
+	Type     string
+	Contacts []model.Contact
+}
+
+func reportCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	format := fs.String("format", "markdown", "Output format: markdown, html")
+	output := fs.String("output", "", "Write the report to this file instead of stdout")
+	all := fs.Bool("all", false, "Include archived contacts")
+
+	return &Command{
+		Name:        "report",
+		Usage:       "apeople report [--format markdown|html] [--output file] [--all]",
+		Description: "Generate a report of all contacts grouped by relationship type",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+
+			var filtered []model.Contact
+			for _, c := range contacts {
+				if !*all && c.State == "archived" {
+					continue
+				}
+				filtered = append(filtered, c)
+			}
+			groups := groupByRelationship(filtered)
+
+			var report string
+			switch *format {
+			case "markdown", "md":
+				report = renderReportMarkdown(groups)
+			case "html":
+				report = renderReportHTML(groups)
+			default:
+				return fmt.Errorf("unsupported format: %s (use markdown or html)", *format)
+			}
+
+			if *output == "" {
+				fmt.Print(report)
+				return nil
+			}
+			if err := os.WriteFile(*output, []byte(report), 0644); err != nil {
+				return fmt.Errorf("failed to write report: %w", err)
+			}
+			if !globalFlags.Quiet {
+				fmt.Printf("Wrote report of %d contacts to %s\n", len(filtered), *output)
+			}
+			return nil
+		},
+	}
+}
+
+// groupByRelationship groups contacts in AllRelationshipTypes order, sorted by
+// name within each group. Unknown or missing types are collected under "other".
+func groupByRelationship(contacts []model.Contact) []reportGroup {
+	byType := map[string][]model.Contact{}
+	for _, c := range contacts {
+		t := string(c.RelationshipType)
+		if !model.ValidRelationshipType(t) {
+			t = "other"
+		}
+		byType[t] = append(byType[t], c)
+	}
+
+	var order []string
+	for _, t := range model.AllRelationshipTypes() {
+		order = append(order, string(t))
+	}
+	order = append(order, "other")
+
+	var groups []reportGroup
+	for _, t := range order {
+		cs := byType[t]
+		if len(cs) == 0 {
+			continue
+		}
+		sort.Slice(cs, func(i, j int) bool {
+			return strings.ToLower(cs[i].Title) < strings.ToLower(cs[j].Title)
+		})
+		groups = append(groups, reportGroup{Type: t, Contacts: cs})
+	}
+	return groups
+}
+
+// lastContactedText describes when a contact was last reached.
+func lastContactedText(c *model.Contact) string {
+	days := c.DaysSinceContact()
+	if c.IsScheduled() {
+		return "scheduled, " + relativeDays(days)
+	}
+	if days < 0 {
+		return "never"
+	}
+	return fmt.Sprintf("%d days ago", days)
+}
+
+func renderReportMarkdown(groups []reportGroup) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Contacts Report\n\nGenerated %s\n", time.Now().Format("January 2, 2006"))
+
+	for _, g := range groups {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", capitalize(g.Type), len(g.Contacts))
+		b.WriteString("| # | Name | Company | State | Last contacted | Status |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, c := range g.Contacts {
+			status := c.OverdueStatus
+			if status == "overdue" {
+				status = "**overdue**"
+			}
+			fmt.Fprintf(&b, "| %d | %s | %s | %s | %s | %s |\n",
+				c.IndexID, markdownCell(c.Title), markdownCell(c.Company), c.State, lastContactedText(&c), status)
+		}
+	}
+
+	return b.String()
+}
+
+const reportCSS = `body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 960px; margin: 2em auto; color: #222; }
+h1 { border-bottom: 2px solid #444; }
+h2 { margin-top: 2em; color: #444; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 4px 8px; border-bottom: 1px solid #ddd; }
+th { background: #f4f4f4; }
+tr.overdue td { background: #fde8e8; }
+tr.attention td { background: #fff6dd; }
+.status-overdue { color: #b00020; font-weight: bold; }
+.status-attention { color: #a66b00; }
+@media print { tr.overdue td, tr.attention td { -webkit-print-color-adjust: exact; print-color-adjust: exact; } }`
+
+func renderReportHTML(groups []reportGroup) string {
+	var b strings.Builder
+	esc := html.EscapeString
+
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<title>Contacts Report</title>\n")
+	fmt.Fprintf(&b, "<style>\n%s\n</style>\n</head>\n<body>\n", reportCSS)
+	fmt.Fprintf(&b, "<h1>Contacts Report</h1>\n<p>Generated %s</p>\n", time.Now().Format("January 2, 2006"))
+
+	for _, g := range groups {
+		fmt.Fprintf(&b, "<h2>%s (%d)</h2>\n", esc(capitalize(g.Type)), len(g.Contacts))
+		b.WriteString("<table>\n<tr><th>#</th><th>Name</th><th>Company</th><th>State</th><th>Last contacted</th><th>Status</th></tr>\n")
+		for _, c := range g.Contacts {
+			fmt.Fprintf(&b, "<tr class=\"%s\"><td>%d</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td class=\"status-%s\">%s</td></tr>\n",
+				esc(c.OverdueStatus), c.IndexID, esc(c.Title), esc(c.Company), esc(c.State),
+				lastContactedText(&c), esc(c.OverdueStatus), esc(c.OverdueStatus))
+		}
+		b.WriteString("</table>\n")
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// capitalize upper-cases the first letter of a relationship type for headings.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
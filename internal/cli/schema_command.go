@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+func schemaCommand() *Command {
+	return &Command{
+		Name:        "schema",
+		Usage:       "apeople schema",
+		Description: "Print a JSON Schema for the contact JSON format",
+		Run: func(cmd *Command, args []string) error {
+			data, err := marshalJSON(model.ContactJSONSchema())
+			if err != nil {
+				return fmt.Errorf("failed to marshal schema: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		},
+	}
+}
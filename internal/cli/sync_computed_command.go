@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// computedSnapshot reports the fields written for one contact.
+type computedSnapshot struct {
+	IndexID       int    `json:"index_id"`
+	Title         string `json:"title"`
+	DaysSince     *int   `json:"days_since_contact,omitempty"`
+	OverdueStatus string `json:"overdue_status,omitempty"`
+}
+
+func syncComputedCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("sync-computed", flag.ContinueOnError)
+	clear := fs.Bool("clear", false, "Remove the computed fields from every contact")
+
+	return &Command{
+		Name:        "sync-computed",
+		Usage:       "apeople sync-computed [--clear]",
+		Description: "Write days_since_contact and overdue_status into contact frontmatter",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			if !*clear && !cfg.PersistComputedFields {
+				return fmt.Errorf("persist_computed_fields is off; enable it in config to write computed fields")
+			}
+
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+
+			written := []computedSnapshot{}
+			for _, c := range contacts {
+				before := snapshotOf(c)
+				if *clear {
+					if c.ComputedAt == "" {
+						continue
+					}
+					parser.ClearComputedFields(&c)
+				} else {
+					parser.StoreComputedFields(&c)
+					if snapshotOf(c) == before {
+						continue
+					}
+				}
+				if err := parser.SaveComputedFields(c); err != nil {
+					return fmt.Errorf("failed to save %s: %w", c.Title, err)
+				}
+				written = append(written, computedSnapshot{
+					IndexID:       c.IndexID,
+					Title:         c.Title,
+					DaysSince:     c.StoredDaysSince,
+					OverdueStatus: c.StoredOverdueStatus,
+				})
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(written)
+				fmt.Println(string(data))
+				return nil
+			}
+			if globalFlags.Quiet {
+				return nil
+			}
+			if *clear {
+				fmt.Printf("Cleared computed fields from %d contacts\n", len(written))
+			} else {
+				fmt.Printf("Updated computed fields on %d of %d contacts\n", len(written), len(contacts))
+			}
+			return nil
+		},
+	}
+}
+
+// snapshotOf flattens a contact's stored computed fields for comparison.
+func snapshotOf(c model.Contact) string {
+	days := "-"
+	if c.StoredDaysSince != nil {
+		days = fmt.Sprint(*c.StoredDaysSince)
+	}
+	return days + "|" + c.StoredOverdueStatus + "|" + c.ComputedAt
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// taggedContact is a contact a batch tag change applies to.
+type taggedContact struct {
+	IndexID int    `json:"index_id"`
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+}
+
+func tagCommand(cfg *config.Config) *Command {
+	cmd := &Command{
+		Name:        "tag",
+		Usage:       "apeople tag <subcommand>",
+		Description: "Add or remove a tag on every contact matching a filter",
+	}
+	cmd.Subcommands = append(cmd.Subcommands,
+		tagBatchCommand(cfg, "add"),
+		tagBatchCommand(cfg, "remove"),
+	)
+	return cmd
+}
+
+// tagBatchCommand builds tag add or tag remove, which change one tag on
+// every contact matching the list-style filters. Without --confirm it only
+// reports what would change; only contacts whose tags change are saved.
+func tagBatchCommand(cfg *config.Config, action string) *Command {
+	fs := flag.NewFlagSet("tag "+action, flag.ContinueOnError)
+	relType := fs.String("type", "", "Only contacts of this relationship type")
+	state := fs.String("state", "", "Only contacts in this state")
+	style := fs.String("style", "", "Only contacts with this contact style")
+	hasTag := fs.String("tag", "", "Only contacts with this tag")
+	search := fs.String("search", "", "Only contacts matching this search, as in list --search")
+	overdue := fs.Bool("overdue", false, "Only overdue contacts")
+	engaged := fs.Bool("engaged", false, "Only contacts in an engagement state")
+	all := fs.Bool("all", false, "Include archived contacts")
+	confirm := fs.Bool("confirm", false, "Apply the change (otherwise only show what would change)")
+
+	verb, done := "Add", "Added #%s to"
+	if action == "remove" {
+		verb, done = "Remove", "Removed #%s from"
+	}
+
+	return &Command{
+		Name:        action,
+		Usage:       fmt.Sprintf("apeople tag %s <tag> [--type t] [--state s] [--style s] [--tag t] [--search q] [--overdue] [--engaged] [--all] --confirm", action),
+		Description: fmt.Sprintf("%s a tag on every contact matching the filters", verb),
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("usage: apeople tag %s <tag> [filters] --confirm", action)
+			}
+			tag := strings.TrimPrefix(strings.TrimSpace(args[0]), "#")
+			if tag == "" || tag == parser.ContactTag() {
+				return fmt.Errorf("invalid tag %q", args[0])
+			}
+			if *relType == "" && *state == "" && *style == "" && *hasTag == "" && *search == "" && !*overdue && !*engaged {
+				return fmt.Errorf("give at least one filter (--type, --state, --style, --tag, --search, --overdue or --engaged)")
+			}
+
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts, err = parser.AssignIndexIDs(cfg.ContactsDirectory, contacts)
+			if err != nil {
+				return err
+			}
+			matched := model.FilterContacts(contacts, model.ContactFilter{
+				Type:            *relType,
+				State:           *state,
+				Style:           *style,
+				Tag:             *hasTag,
+				Search:          *search,
+				Overdue:         *overdue,
+				Engaged:         *engaged,
+				IncludeArchived: *all,
+			})
+
+			changed := []taggedContact{}
+			for i := range matched {
+				c := &matched[i]
+				if slices.Contains(c.Tags, tag) == (action == "add") {
+					continue
+				}
+				if *confirm {
+					if action == "add" {
+						acore.AddRelation(&c.Tags, tag)
+					} else {
+						acore.RemoveRelation(&c.Tags, tag)
+					}
+					if err := parser.SaveContactFile(*c); err != nil {
+						return fmt.Errorf("failed to save %s: %w", c.Title, err)
+					}
+				}
+				changed = append(changed, taggedContact{IndexID: c.IndexID, ID: c.ID, Title: c.Title})
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(map[string]interface{}{
+					"tag":     tag,
+					"action":  action,
+					"matched": len(matched),
+					"changed": changed,
+					"applied": *confirm,
+				})
+				fmt.Println(string(data))
+			} else if !globalFlags.Quiet {
+				for _, c := range changed {
+					fmt.Printf("  #%d %s\n", c.IndexID, c.Title)
+				}
+				if *confirm {
+					fmt.Printf(done+" %d of %d matching contacts\n", tag, len(changed), len(matched))
+				} else {
+					fmt.Printf("Would %s #%s on %d of %d matching contacts\n", action, tag, len(changed), len(matched))
+				}
+			}
+
+			if !*confirm && len(changed) > 0 {
+				return fmt.Errorf("use --confirm to %s #%s on %d contacts", action, tag, len(changed))
+			}
+			return nil
+		},
+	}
+}
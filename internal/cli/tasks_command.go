@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+	"github.com/mph-llm-experiments/apeople/internal/tasks"
+)
+
+// taskGap is a contact in a task state with no open task for it.
+type taskGap struct {
+	IndexID int    `json:"index_id"`
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Created bool   `json:"task_created"`
+}
+
+func tasksCommand(cfg *config.Config) *Command {
+	cmd := &Command{
+		Name:        "tasks",
+		Usage:       "apeople tasks <subcommand>",
+		Description: "Reconcile contacts with their atask follow-up tasks",
+	}
+	cmd.Subcommands = append(cmd.Subcommands, tasksCheckCommand(cfg))
+	return cmd
+}
+
+func tasksCheckCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("tasks check", flag.ContinueOnError)
+	fix := fs.Bool("fix", false, "Create the missing tasks")
+
+	return &Command{
+		Name:        "check",
+		Usage:       "apeople tasks check [--fix]",
+		Description: "List contacts in a task state (e.g. followup, ping) with no open task",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			contacts, err = parser.AssignIndexIDs(cfg.ContactsDirectory, contacts)
+			if err != nil {
+				return err
+			}
+			open, err := tasks.Open(cfg)
+			if err != nil {
+				return err
+			}
+
+			// A task belongs to a contact if either side links the other
+			hasTask := map[string]bool{}
+			openIDs := map[string]bool{}
+			for _, t := range open {
+				openIDs[t.ID] = true
+				for _, id := range t.People {
+					hasTask[id] = true
+				}
+			}
+
+			gaps := []taskGap{}
+			checked := 0
+			for _, c := range contacts {
+				if !cfg.CreatesTaskFor(c.State) {
+					continue
+				}
+				checked++
+				if hasTask[c.ID] || slices.ContainsFunc(c.RelatedTasks, func(id string) bool { return openIDs[id] }) {
+					continue
+				}
+				gap := taskGap{IndexID: c.IndexID, ID: c.ID, Title: c.Title, State: c.State}
+				if *fix {
+					gap.Created, err = tasks.CreateForContact(cfg, c, c.State)
+					if err != nil {
+						return fmt.Errorf("failed to create task for %s: %w", c.Title, err)
+					}
+				}
+				gaps = append(gaps, gap)
+			}
+			sort.Slice(gaps, func(i, j int) bool { return gaps[i].IndexID < gaps[j].IndexID })
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(map[string]interface{}{
+					"checked": checked,
+					"missing": gaps,
+					"fixed":   *fix,
+				})
+				fmt.Println(string(data))
+			} else if !globalFlags.Quiet {
+				if cfg.CreateTasks != nil && !*cfg.CreateTasks {
+					fmt.Fprintln(os.Stderr, "Warning: create_tasks is false in config; no state calls for a task")
+				}
+				for _, g := range gaps {
+					line := fmt.Sprintf("  #%d %s (%s): no open task", g.IndexID, g.Title, g.State)
+					if g.Created {
+						line += " [task created]"
+					}
+					fmt.Println(line)
+				}
+				if *fix {
+					fmt.Printf("Created tasks for %d of %d contacts in task states\n", len(gaps), checked)
+				} else {
+					fmt.Printf("%d of %d contacts in task states have no open task\n", len(gaps), checked)
+				}
+			}
+
+			if len(gaps) > 0 && !*fix {
+				return fmt.Errorf("missing tasks found (run with --fix to create them)")
+			}
+			return nil
+		},
+	}
+}
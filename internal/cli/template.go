@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// templateFuncs are available to --template in addition to the text/template
+// builtins. Contacts are passed as *model.Contact, so methods such as
+// .DaysSinceContact and .IsOverdue can be called directly too.
+var templateFuncs = template.FuncMap{
+	"daysSince": func(c *model.Contact) int { return c.DaysSinceContact() },
+	"dueDate":   dueDate,
+	"relative":  relativeDays,
+	"join":      strings.Join,
+	"upper":     strings.ToUpper,
+	"lower":     strings.ToLower,
+}
+
+// dueDate returns the date the contact is next due (YYYY-MM-DD), or "" if it
+// has no frequency or has never been contacted.
+func dueDate(c *model.Contact) string {
+	freq := c.GetFrequencyDays()
+	if freq <= 0 || c.LastContacted == nil {
+		return ""
+	}
+	return c.LastContacted.AddDate(0, 0, freq).Format("2006-01-02")
+}
+
+// templateFlags registers --template and --template-file on fs.
+func templateFlags(fs *flag.FlagSet) (text, file *string) {
+	text = fs.String("template", "", "Render each contact with this Go text/template")
+	file = fs.String("template-file", "", "Read the --template from a file")
+	return text, file
+}
+
+// parseTemplate builds the output template from --template or --template-file.
+// Returns nil if neither was given.
+func parseTemplate(text, file string) (*template.Template, error) {
+	if text != "" && file != "" {
+		return nil, fmt.Errorf("use either --template or --template-file, not both")
+	}
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		text = string(data)
+	}
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("contact").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// renderTemplate writes each contact through tmpl, one per line.
+func renderTemplate(tmpl *template.Template, contacts []model.Contact) error {
+	var b strings.Builder
+	for i := range contacts {
+		c := &contacts[i]
+		b.Reset()
+		if err := tmpl.Execute(&b, c); err != nil {
+			return fmt.Errorf("template failed for #%d %s: %w", c.IndexID, c.Title, err)
+		}
+		out := b.String()
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		fmt.Print(out)
+	}
+	return nil
+}
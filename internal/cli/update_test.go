@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// seedUpdateContact writes contact #1 with every field update can set and
+// returns a config pointing at its directory.
+func seedUpdateContact(t *testing.T) *config.Config {
+	t.Helper()
+	dir := t.TempDir()
+	lead := 5
+	c := model.Contact{
+		Email:               "ada@example.com",
+		Phone:               "555 0100",
+		RelationshipType:    model.RelationshipWork,
+		State:               string(model.StateOk),
+		ContactStyle:        model.StylePeriodic,
+		Company:             "Engines Ltd",
+		Role:                "Analyst",
+		Location:            "London",
+		Avatar:              "https://example.com/ada.png",
+		CustomFrequencyDays: 45,
+		ReminderLeadDays:    &lead,
+	}
+	c.ID = acore.NewID()
+	c.Title = "Ada Lovelace"
+	c.IndexID = 1
+	c.Type = parser.ContactTag()
+	c.Tags = []string{parser.ContactTag(), "math"}
+	c.Created = acore.Now()
+	c.PlannedFor = "2026-12-01"
+	c.FilePath = parser.GenerateFilePath(dir, c)
+	if err := parser.SaveContactFile(c); err != nil {
+		t.Fatalf("seed contact: %v", err)
+	}
+
+	quiet := globalFlags.Quiet
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = quiet })
+	return &config.Config{ContactsDirectory: dir}
+}
+
+// loadUpdateContact reads contact #1 back from disk.
+func loadUpdateContact(t *testing.T, cfg *config.Config) model.Contact {
+	t.Helper()
+	contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+	if err != nil || len(contacts) != 1 {
+		t.Fatalf("reload: %d contacts, %v", len(contacts), err)
+	}
+	return contacts[0]
+}
+
+// runUpdate runs `update 1 args...` and returns the saved contact.
+func runUpdate(t *testing.T, cfg *config.Config, args ...string) (model.Contact, error) {
+	t.Helper()
+	err := updateCommand(cfg).Execute(append([]string{"1"}, args...))
+	return loadUpdateContact(t, cfg), err
+}
+
+func TestUpdateFlags(t *testing.T) {
+	leadDays := func(c model.Contact) string {
+		if c.ReminderLeadDays == nil {
+			return ""
+		}
+		return strconv.Itoa(*c.ReminderLeadDays)
+	}
+	fields := map[string]func(model.Contact) string{
+		"name":      func(c model.Contact) string { return c.Title },
+		"type":      func(c model.Contact) string { return string(c.RelationshipType) },
+		"style":     func(c model.Contact) string { return string(c.ContactStyle) },
+		"email":     func(c model.Contact) string { return c.Email },
+		"phone":     func(c model.Contact) string { return c.Phone },
+		"company":   func(c model.Contact) string { return c.Company },
+		"role":      func(c model.Contact) string { return c.Role },
+		"location":  func(c model.Contact) string { return c.Location },
+		"avatar":    func(c model.Contact) string { return c.Avatar },
+		"frequency": func(c model.Contact) string { return strconv.Itoa(c.CustomFrequencyDays) },
+		"lead":      leadDays,
+		"state":     func(c model.Contact) string { return c.State },
+		"tags":      func(c model.Contact) string { return strings.Join(c.Tags, ",") },
+		"plan-for":  func(c model.Contact) string { return c.PlannedFor },
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		field string
+		want  string
+	}{
+		{"set name", []string{"--name", "Ada King"}, "name", "Ada King"},
+		{"set type", []string{"--type", "close"}, "type", "close"},
+		{"set style", []string{"--style", "ambient"}, "style", "ambient"},
+		{"clear style", []string{"--style", ""}, "style", ""},
+		{"set email", []string{"--email", "ada@new.example"}, "email", "ada@new.example"},
+		{"clear email", []string{"--email", ""}, "email", ""},
+		{"set phone", []string{"--phone", "555 0199"}, "phone", "555 0199"},
+		{"clear phone", []string{"--phone", ""}, "phone", ""},
+		{"set company", []string{"--company", "Babbage & Co"}, "company", "Babbage & Co"},
+		{"clear company", []string{"--company", ""}, "company", ""},
+		{"set role", []string{"--role", "Programmer"}, "role", "Programmer"},
+		{"clear role", []string{"--role", ""}, "role", ""},
+		{"set location", []string{"--location", "Paris"}, "location", "Paris"},
+		{"clear location", []string{"--location", ""}, "location", ""},
+		{"set avatar", []string{"--avatar", "https://example.com/new.png"}, "avatar", "https://example.com/new.png"},
+		{"clear avatar", []string{"--avatar", ""}, "avatar", ""},
+		{"clear avatar with none", []string{"--avatar", "none"}, "avatar", ""},
+		{"set frequency", []string{"--frequency", "14"}, "frequency", "14"},
+		{"clear frequency", []string{"--frequency", "0"}, "frequency", "0"},
+		{"set lead", []string{"--lead", "3"}, "lead", "3"},
+		{"clear lead", []string{"--lead", ""}, "lead", ""},
+		{"clear lead with none", []string{"--lead", "none"}, "lead", ""},
+		{"set state", []string{"--state", "ping"}, "state", "ping"},
+		{"set tags", []string{"--tags", "poetry, math"}, "tags", "contact,poetry,math"},
+		{"clear tags", []string{"--tags", ""}, "tags", "contact"},
+		{"set plan-for", []string{"--plan-for", "2027-01-15"}, "plan-for", "2027-01-15"},
+		{"clear plan-for", []string{"--plan-for", ""}, "plan-for", ""},
+		{"clear plan-for with none", []string{"--plan-for", "none"}, "plan-for", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := seedUpdateContact(t)
+			before := loadUpdateContact(t, cfg)
+			after, err := runUpdate(t, cfg, tt.args...)
+			if err != nil {
+				t.Fatalf("update %q: %v", tt.args, err)
+			}
+			if got := fields[tt.field](after); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.field, got, tt.want)
+			}
+			// Every other field is left as it was
+			for name, get := range fields {
+				if name != tt.field && get(after) != get(before) {
+					t.Errorf("%s changed from %q to %q", name, get(before), get(after))
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateNoFlagsChangesNothing(t *testing.T) {
+	cfg := seedUpdateContact(t)
+	before := loadUpdateContact(t, cfg)
+	after, err := runUpdate(t, cfg)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	before.Modified, after.Modified = "", ""
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("update without flags changed the contact:\nbefore %+v\nafter  %+v", before, after)
+	}
+}
+
+func TestUpdateRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--style", "weekly"}, `invalid --style "weekly" (use periodic, ambient, triggered)`},
+		{[]string{"--type", "bogus"}, `invalid --type "bogus"`},
+		{[]string{"--type", ""}, `invalid --type ""`},
+		{[]string{"--state", "bogus"}, `unknown state "bogus"`},
+		{[]string{"--name", ""}, "--name must not be empty"},
+		{[]string{"--frequency", "-1"}, "--frequency must not be negative"},
+		{[]string{"--lead", "soon"}, `invalid --lead "soon"`},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			cfg := seedUpdateContact(t)
+			path := loadUpdateContact(t, cfg).FilePath
+			before, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := runUpdate(t, cfg, tt.args...); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.want)
+			}
+			if after, _ := os.ReadFile(path); !bytes.Equal(before, after) {
+				t.Error("a rejected update still changed the file")
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// upgradedContact records a contact moved to the current schema.
+type upgradedContact struct {
+	IndexID     int      `json:"index_id"`
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	FromVersion int      `json:"from_version"`
+	Steps       []string `json:"steps"`
+}
+
+func upgradeCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("upgrade", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "Report contacts that need upgrading without changing files")
+
+	return &Command{
+		Name:        "upgrade",
+		Usage:       "apeople upgrade [--dry-run]",
+		Description: "Migrate contact files to the current frontmatter schema",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			contacts, err := parser.FindContacts(cfg.ContactsDirectory)
+			if err != nil {
+				return err
+			}
+			if *dryRun {
+				contacts = parser.AssignIndexIDsInMemory(cfg.ContactsDirectory, contacts)
+			} else if contacts, err = parser.AssignIndexIDs(cfg.ContactsDirectory, contacts); err != nil {
+				return err
+			}
+
+			upgraded := []upgradedContact{}
+			for _, c := range contacts {
+				from := parser.SchemaVersionOf(c)
+				steps := parser.UpgradeContact(&c)
+				if len(steps) == 0 {
+					continue
+				}
+				if !*dryRun {
+					if err := parser.SaveContactFile(c); err != nil {
+						return fmt.Errorf("failed to upgrade %s: %w", c.Title, err)
+					}
+				}
+				upgraded = append(upgraded, upgradedContact{IndexID: c.IndexID, ID: c.ID, Title: c.Title, FromVersion: from, Steps: steps})
+			}
+
+			if globalFlags.JSON {
+				data, _ := marshalJSON(map[string]interface{}{
+					"schema_version": parser.CurrentSchemaVersion,
+					"upgraded":       upgraded,
+					"dry_run":        *dryRun,
+				})
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if globalFlags.Quiet {
+				return nil
+			}
+			verb := "Upgraded"
+			if *dryRun {
+				verb = "Would upgrade"
+			}
+			for _, u := range upgraded {
+				fmt.Printf("  #%-4d %s (v%d -> v%d)\n", u.IndexID, u.Title, u.FromVersion, parser.CurrentSchemaVersion)
+			}
+			fmt.Printf("%s %d of %d contacts to schema v%d\n", verb, len(upgraded), len(contacts), parser.CurrentSchemaVersion)
+			return nil
+		},
+	}
+}
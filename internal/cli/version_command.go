@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func versionCommand() *Command {
+	return &Command{
+		Name:        "version",
+		Usage:       "apeople version",
+		Description: "Show version information",
+		Run: func(cmd *Command, args []string) error {
+			if globalFlags.JSON {
+				result := map[string]string{
+					"version":    Version,
+					"go_version": runtime.Version(),
+				}
+				data, _ := marshalJSON(result)
+				fmt.Println(string(data))
+				return nil
+			}
+
+			fmt.Printf("apeople v%s (%s)\n", Version, runtime.Version())
+			return nil
+		},
+	}
+}
@@ -1,14 +1,152 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/mph-llm-experiments/apeople/internal/model"
 )
 
 type Config struct {
 	ContactsDirectory string `toml:"contacts_directory"`
+
+	// Named contacts directories, so `--dir work` can stand for a path
+	Vaults map[string]string `toml:"vaults"`
+
+	// Defaults for newly created contacts
+	DefaultType  string `toml:"default_type"`
+	DefaultStyle string `toml:"default_style"`
+	DefaultState string `toml:"default_state"`
+
+	// Marker tag for contact files (filename keyword and first tag)
+	ContactTag string `toml:"contact_tag"`
+
+	// Frontmatter format written when saving contacts: yaml (default), toml or json.
+	// Files in any of these formats are always readable.
+	FrontmatterFormat string `toml:"frontmatter_format"`
+
+	// IANA time zone (e.g. "America/Los_Angeles") for "today", days-since and
+	// date formatting; empty uses the machine's local zone
+	Timezone string         `toml:"timezone"`
+	Location *time.Location `toml:"-"`
+
+	// Render days-since and due dates as relative time ("3 weeks ago") in text output
+	RelativeDates bool `toml:"relative_dates"`
+
+	// Go text/template rendered with the contact's fields into the body of
+	// every new contact
+	NewContactTemplate string `toml:"new_contact_template"`
+
+	// Group interaction log entries under "### YYYY" headings
+	LogByYear bool `toml:"log_by_year"`
+
+	// Headings of the body sections apeople reads and writes, for files
+	// kept in another language or convention
+	InteractionLogHeader string `toml:"interaction_log_header"`
+	NotesHeader          string `toml:"notes_header"`
+
+	// Command run when apeople is invoked with no arguments, e.g. "list" or
+	// "list --overdue"; "tui" (the default) launches the TUI
+	DefaultCommand string `toml:"default_command"`
+
+	// Relationship types beyond the built-ins, or overrides of a built-in's
+	// default frequency, as name = days (0 for no default)
+	RelationshipTypes map[string]int `toml:"relationship_types"`
+
+	// Relationship types from most distant to closest, the steps `promote`
+	// and `demote` move a contact along
+	TypeLadder []string `toml:"type_ladder"`
+
+	// Bounds for the effective contact frequency in days; 0 means no bound.
+	// Out-of-range custom frequencies are clamped and warned about.
+	MinFrequencyDays int `toml:"min_frequency_days"`
+	MaxFrequencyDays int `toml:"max_frequency_days"`
+
+	// Include archived contacts in `list --json` without --all
+	ListIncludeArchived bool `toml:"list_include_archived"`
+
+	// Allow `sync-computed` to write days_since_contact and overdue_status
+	// into frontmatter (denormalized; off by default)
+	PersistComputedFields bool `toml:"persist_computed_fields"`
+
+	// Automatic follow-up task creation on state changes
+	CreateTasks *bool    `toml:"create_tasks"` // nil means enabled
+	TaskStates  []string `toml:"task_states"`
+
+	// Where follow-up tasks are written; empty uses atask's directory from
+	// the acore config
+	TaskDirectory string `toml:"task_directory"`
+
+	// Go text/templates for the task title and its tag, rendered with
+	// .Name, .State, .Action (e.g. "Ping") and .Label. An empty tag template
+	// (nil means the default) writes tasks without a tag.
+	TaskTitleTemplate string  `toml:"task_title_template"`
+	TaskTagTemplate   *string `toml:"task_tag_template"`
+
+	// Where the settings came from, for `apeople config`
+	Path            string `toml:"-"` // config file read, empty when none
+	Source          string `toml:"-"` // SourceFlag, SourceFile, SourceLegacy or SourceDefaults
+	DirectorySource string `toml:"-"` // what set ContactsDirectory
+}
+
+// Config sources reported by `apeople config`.
+const (
+	SourceFlag     = "--config"
+	SourceFile     = "config file"
+	SourceLegacy   = "legacy config"
+	SourceDefaults = "defaults"
+)
+
+// contactTagRe matches a valid Denote filename keyword.
+var contactTagRe = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// relationshipTypeRe matches a valid relationship type name.
+var relationshipTypeRe = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// sectionHeaderRe matches a level-2 Markdown heading on one line.
+var sectionHeaderRe = regexp.MustCompile(`^## [^\n]*\S$`)
+
+// Default body section headings.
+const (
+	DefaultInteractionLogHeader = "## Interaction Log"
+	DefaultNotesHeader          = "## Notes"
+)
+
+// DefaultTypeLadder is the promote/demote order when type_ladder is unset.
+var DefaultTypeLadder = []string{"network", "work", "close"}
+
+// Default task templates, matching the titles and tags tasks always had.
+const (
+	DefaultTaskTitleTemplate = `{{.Action}} {{.Name}}{{if eq .State "timeout"}} (no response){{end}}`
+	DefaultTaskTagTemplate   = `contact-{{.State}}`
+)
+
+// DefaultTaskStates are the states that create a follow-up task when task_states is unset.
+var DefaultTaskStates = []string{"followup", "ping", "scheduled", "timeout"}
+
+// VaultDir returns the directory of the named vault, if there is one.
+func (c *Config) VaultDir(name string) (string, bool) {
+	dir, ok := c.Vaults[name]
+	return dir, ok
+}
+
+// CreatesTaskFor reports whether changing a contact to state should create a task.
+func (c *Config) CreatesTaskFor(state string) bool {
+	if c.CreateTasks != nil && !*c.CreateTasks {
+		return false
+	}
+	for _, s := range c.TaskStates {
+		if s == state {
+			return true
+		}
+	}
+	return false
 }
 
 func Load(configPath string) (*Config, error) {
@@ -24,22 +162,26 @@ func Load(configPath string) (*Config, error) {
 		if _, err := toml.DecodeFile(configPath, config); err != nil {
 			return nil, err
 		}
+		config.Path, config.Source, config.DirectorySource = configPath, SourceFlag, SourceFlag
 		expandTilde(config, homeDir)
-		return config, nil
+		return config, applyDefaults(config)
 	}
 
+	configHome := configBaseDir(homeDir)
+
 	// Try new config path first
-	newConfigPath := filepath.Join(homeDir, ".config", "apeople", "config.toml")
+	newConfigPath := filepath.Join(configHome, "apeople", "config.toml")
 	if _, err := os.Stat(newConfigPath); err == nil {
 		if _, err := toml.DecodeFile(newConfigPath, config); err != nil {
 			return nil, err
 		}
+		config.Path, config.Source, config.DirectorySource = newConfigPath, SourceFile, SourceFile
 		expandTilde(config, homeDir)
-		return config, nil
+		return config, applyDefaults(config)
 	}
 
 	// Fallback to legacy config path
-	legacyConfigPath := filepath.Join(homeDir, ".config", "denote-contacts", "config.toml")
+	legacyConfigPath := filepath.Join(configHome, "denote-contacts", "config.toml")
 	if _, err := os.Stat(legacyConfigPath); err == nil {
 		// Legacy config uses notes_directory key
 		var legacyConfig struct {
@@ -54,17 +196,138 @@ func Load(configPath string) (*Config, error) {
 		} else {
 			config.ContactsDirectory = legacyConfig.NotesDirectory
 		}
+		config.Path, config.Source, config.DirectorySource = legacyConfigPath, SourceLegacy, SourceLegacy
 		expandTilde(config, homeDir)
-		return config, nil
+		return config, applyDefaults(config)
 	}
 
 	// Use defaults if no config file
 	config.ContactsDirectory = filepath.Join(homeDir, "Documents", "denote")
-	return config, nil
+	config.Source, config.DirectorySource = SourceDefaults, SourceDefaults
+	return config, applyDefaults(config)
+}
+
+// applyDefaults fills in unset options and validates the configured values.
+func applyDefaults(config *Config) error {
+	if config.DefaultType == "" {
+		config.DefaultType = string(model.RelationshipNetwork)
+	}
+	if config.DefaultStyle == "" {
+		config.DefaultStyle = string(model.StylePeriodic)
+	}
+	if config.DefaultState == "" {
+		config.DefaultState = string(model.StateOk)
+	}
+	if config.ContactTag == "" {
+		config.ContactTag = "contact"
+	}
+	if config.InteractionLogHeader == "" {
+		config.InteractionLogHeader = DefaultInteractionLogHeader
+	}
+	if config.NotesHeader == "" {
+		config.NotesHeader = DefaultNotesHeader
+	}
+	if config.TaskStates == nil {
+		config.TaskStates = DefaultTaskStates
+	}
+	if config.TaskTitleTemplate == "" {
+		config.TaskTitleTemplate = DefaultTaskTitleTemplate
+	}
+	if config.TaskTagTemplate == nil {
+		tag := DefaultTaskTagTemplate
+		config.TaskTagTemplate = &tag
+	}
+	if config.TypeLadder == nil {
+		config.TypeLadder = DefaultTypeLadder
+	}
+	if strings.TrimSpace(config.DefaultCommand) == "" {
+		config.DefaultCommand = "tui"
+	}
+
+	for name, days := range config.RelationshipTypes {
+		if !relationshipTypeRe.MatchString(name) {
+			return fmt.Errorf("invalid relationship type name %q (use lowercase letters, digits, - and _)", name)
+		}
+		if days < 0 {
+			return fmt.Errorf("relationship type %q: frequency must not be negative", name)
+		}
+	}
+	if _, custom := config.RelationshipTypes[config.DefaultType]; !custom && !model.ValidRelationshipType(config.DefaultType) {
+		return fmt.Errorf("invalid default_type %q", config.DefaultType)
+	}
+	if len(config.TypeLadder) < 2 {
+		return fmt.Errorf("type_ladder needs at least two relationship types")
+	}
+	onLadder := map[string]bool{}
+	for _, t := range config.TypeLadder {
+		if _, custom := config.RelationshipTypes[t]; !custom && !model.ValidRelationshipType(t) {
+			return fmt.Errorf("invalid type_ladder entry %q", t)
+		}
+		if onLadder[t] {
+			return fmt.Errorf("type_ladder lists %q more than once", t)
+		}
+		onLadder[t] = true
+	}
+	if !model.ValidContactStyle(config.DefaultStyle) {
+		return fmt.Errorf("invalid default_style %q", config.DefaultStyle)
+	}
+	if !contactTagRe.MatchString(config.ContactTag) {
+		return fmt.Errorf("invalid contact_tag %q (use lowercase letters and digits)", config.ContactTag)
+	}
+	for key, header := range map[string]string{
+		"interaction_log_header": config.InteractionLogHeader,
+		"notes_header":           config.NotesHeader,
+	} {
+		if !sectionHeaderRe.MatchString(header) {
+			return fmt.Errorf("invalid %s %q (use a level-2 heading like \"## Notes\")", key, header)
+		}
+	}
+	if config.InteractionLogHeader == config.NotesHeader {
+		return fmt.Errorf("interaction_log_header and notes_header must differ")
+	}
+	if !model.ValidState(config.DefaultState) {
+		return fmt.Errorf("invalid default_state %q", config.DefaultState)
+	}
+	if config.MinFrequencyDays < 0 || config.MaxFrequencyDays < 0 {
+		return fmt.Errorf("min_frequency_days and max_frequency_days must not be negative")
+	}
+	if config.MaxFrequencyDays > 0 && config.MinFrequencyDays > config.MaxFrequencyDays {
+		return fmt.Errorf("min_frequency_days (%d) is greater than max_frequency_days (%d)", config.MinFrequencyDays, config.MaxFrequencyDays)
+	}
+	if _, err := template.New("").Parse(config.TaskTitleTemplate); err != nil {
+		return fmt.Errorf("invalid task_title_template: %w", err)
+	}
+	if _, err := template.New("").Parse(*config.TaskTagTemplate); err != nil {
+		return fmt.Errorf("invalid task_tag_template: %w", err)
+	}
+	if config.Timezone != "" {
+		loc, err := time.LoadLocation(config.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone %q: %w", config.Timezone, err)
+		}
+		config.Location = loc
+	}
+	return nil
+}
+
+// configBaseDir returns $XDG_CONFIG_HOME if set, otherwise ~/.config.
+func configBaseDir(homeDir string) string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return xdg
+	}
+	return filepath.Join(homeDir, ".config")
 }
 
 func expandTilde(config *Config, homeDir string) {
 	if len(config.ContactsDirectory) > 0 && config.ContactsDirectory[0] == '~' {
 		config.ContactsDirectory = filepath.Join(homeDir, config.ContactsDirectory[1:])
 	}
+	if len(config.TaskDirectory) > 0 && config.TaskDirectory[0] == '~' {
+		config.TaskDirectory = filepath.Join(homeDir, config.TaskDirectory[1:])
+	}
+	for name, dir := range config.Vaults {
+		if len(dir) > 0 && dir[0] == '~' {
+			config.Vaults[name] = filepath.Join(homeDir, dir[1:])
+		}
+	}
 }
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// NextBirthday returns the contact's next birthday on or after the day of
+// from, and the age they turn then (0 when the birthday has no year).
+// Birthdays are YYYY-MM-DD or MM-DD; a Feb 29 birthday falls on Feb 28 in
+// common years. ok is false when there's no parseable birthday.
+func (c *Contact) NextBirthday(from time.Time) (next time.Time, age int, ok bool) {
+	year, month, day, ok := parseBirthday(c.Birthday)
+	if !ok {
+		return time.Time{}, 0, false
+	}
+
+	today := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for y := today.Year(); ; y++ {
+		d := day
+		if month == time.February && day == 29 && !isLeap(y) {
+			d = 28
+		}
+		next = time.Date(y, month, d, 0, 0, 0, 0, from.Location())
+		if !next.Before(today) {
+			break
+		}
+	}
+	if year > 0 {
+		age = next.Year() - year
+	}
+	return next, age, true
+}
+
+// parseBirthday reads YYYY-MM-DD or MM-DD; year is 0 for the latter.
+func parseBirthday(s string) (year int, month time.Month, day int, ok bool) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t.Year(), t.Month(), t.Day(), true
+	}
+	// Parse MM-DD against a leap year so 02-29 is accepted
+	if t, err := time.Parse("2006-01-02", "2000-"+strings.TrimPrefix(s, "--")); err == nil {
+		return 0, t.Month(), t.Day(), true
+	}
+	return 0, 0, 0, false
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -1,6 +1,9 @@
 package model
 
 import (
+	"math"
+	"slices"
+	"sort"
 	"time"
 
 	"github.com/mph-llm-experiments/acore"
@@ -19,6 +22,44 @@ const (
 	RelationshipRecruiters RelationshipType = "recruiters" // No default
 )
 
+// RelationshipTypes lists the built-in relationship types
+var RelationshipTypes = []RelationshipType{
+	RelationshipClose, RelationshipFamily, RelationshipNetwork, RelationshipWork,
+	RelationshipSocial, RelationshipProviders, RelationshipRecruiters,
+}
+
+// customRelationshipTypes are the extra types defined in config
+var customRelationshipTypes []RelationshipType
+
+// SetCustomRelationshipTypes registers relationship types beyond the
+// built-ins, e.g. from the [relationship_types] config table. Built-in names
+// are ignored.
+func SetCustomRelationshipTypes(types []RelationshipType) {
+	customRelationshipTypes = nil
+	for _, t := range types {
+		if !slices.Contains(RelationshipTypes, t) && !slices.Contains(customRelationshipTypes, t) {
+			customRelationshipTypes = append(customRelationshipTypes, t)
+		}
+	}
+}
+
+// AllRelationshipTypes returns the built-in relationship types followed by
+// any configured ones
+func AllRelationshipTypes() []RelationshipType {
+	return append(slices.Clone(RelationshipTypes), customRelationshipTypes...)
+}
+
+// ValidRelationshipType reports whether t is a built-in or configured
+// relationship type
+func ValidRelationshipType(t string) bool {
+	for _, rt := range AllRelationshipTypes() {
+		if string(rt) == t {
+			return true
+		}
+	}
+	return false
+}
+
 // ContactStyle defines how contact reminders work
 type ContactStyle string
 
@@ -28,18 +69,61 @@ const (
 	StyleTriggered ContactStyle = "triggered" // Event-based
 )
 
+// ContactStyles lists the supported contact styles
+var ContactStyles = []ContactStyle{StylePeriodic, StyleAmbient, StyleTriggered}
+
+// ValidContactStyle reports whether s is a known contact style
+func ValidContactStyle(s string) bool {
+	for _, cs := range ContactStyles {
+		if string(cs) == s {
+			return true
+		}
+	}
+	return false
+}
+
 // ContactState represents the current state of a contact
 type ContactState string
 
 const (
-	StateOk       ContactState = "ok"       // At rest, no action needed
-	StatePing     ContactState = "ping"     // I want to reach out
-	StateFollowup ContactState = "followup" // I owe them a response or action
-	StateWaiting  ContactState = "waiting"  // Ball's in their court
-	StateSked     ContactState = "sked"     // Scheduling something
-	StateArchived ContactState = "archived" // Inactive/dormant
+	StateOk        ContactState = "ok"        // At rest, no action needed
+	StatePing      ContactState = "ping"      // I want to reach out
+	StateFollowup  ContactState = "followup"  // I owe them a response or action
+	StateWaiting   ContactState = "waiting"   // Ball's in their court
+	StateSked      ContactState = "sked"      // Scheduling something
+	StateScheduled ContactState = "scheduled" // Meeting/call is on the calendar
+	StateTimeout   ContactState = "timeout"   // No response
+	StateArchived  ContactState = "archived"  // Inactive/dormant
 )
 
+// ContactStates lists the known contact states
+var ContactStates = []ContactState{
+	StateOk, StatePing, StateFollowup, StateWaiting, StateSked,
+	StateScheduled, StateTimeout, StateArchived,
+}
+
+// ValidState reports whether s is a known contact state
+func ValidState(s string) bool {
+	for _, st := range ContactStates {
+		if string(st) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidTransition reports whether a contact may move from one state to another.
+// Archived contacts must be restored to ok before entering any other state.
+func ValidTransition(from, to string) bool {
+	if from == to {
+		return true
+	}
+	if from == string(StateArchived) {
+		return to == string(StateOk)
+	}
+	return true
+}
+
 // InteractionType represents types of interactions
 type InteractionType string
 
@@ -51,8 +135,34 @@ const (
 	InteractionSocial  InteractionType = "social"
 	InteractionBump    InteractionType = "bump"
 	InteractionNote    InteractionType = "note"
+
+	// InteractionReclassified marks a relationship type change made by
+	// promote or demote; it's written by those commands, not accepted by log
+	InteractionReclassified InteractionType = "reclassified"
 )
 
+// InteractionTypes lists the interaction types `log` accepts
+var InteractionTypes = []InteractionType{
+	InteractionEmail, InteractionCall, InteractionText, InteractionMeeting,
+	InteractionSocial, InteractionBump, InteractionNote,
+}
+
+// ValidInteractionType reports whether t is a known interaction type
+func ValidInteractionType(t string) bool {
+	for _, it := range InteractionTypes {
+		if string(it) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// LabeledValue is a contact detail with an optional label, e.g. a work email.
+type LabeledValue struct {
+	Label string `yaml:"label,omitempty" json:"label,omitempty"`
+	Value string `yaml:"value" json:"value"`
+}
+
 // Contact represents a contact record.
 // Embeds acore.Entity for common fields (id, title, index_id, type, tags,
 // created, modified, related_people, related_tasks, related_ideas, file_path).
@@ -62,6 +172,8 @@ type Contact struct {
 	// Domain-specific fields
 	Email            string           `yaml:"email,omitempty" json:"email,omitempty"`
 	Phone            string           `yaml:"phone,omitempty" json:"phone,omitempty"`
+	Emails           []LabeledValue   `yaml:"emails,omitempty" json:"emails,omitempty"`
+	Phones           []LabeledValue   `yaml:"phones,omitempty" json:"phones,omitempty"`
 	RelationshipType RelationshipType `yaml:"relationship_type" json:"relationship_type"`
 	State            string           `yaml:"state,omitempty" json:"state,omitempty"`
 	Label            string           `yaml:"label,omitempty" json:"label,omitempty"`
@@ -71,6 +183,7 @@ type Contact struct {
 	BumpCount        int              `yaml:"bump_count,omitempty" json:"bump_count,omitempty"`
 
 	// Optional fields
+	Aliases              []string `yaml:"aliases,omitempty" json:"aliases,omitempty"` // other names the contact can be looked up by
 	Company              string   `yaml:"company,omitempty" json:"company,omitempty"`
 	Role                 string   `yaml:"role,omitempty" json:"role,omitempty"`
 	Location             string   `yaml:"location,omitempty" json:"location,omitempty"`
@@ -78,99 +191,184 @@ type Contact struct {
 	LinkedIn             string   `yaml:"linkedin,omitempty" json:"linkedin,omitempty"`
 	Twitter              string   `yaml:"twitter,omitempty" json:"twitter,omitempty"`
 	Website              string   `yaml:"website,omitempty" json:"website,omitempty"`
+	Avatar               string   `yaml:"avatar,omitempty" json:"avatar,omitempty"` // photo: a local image path or a URL
 	Notes                string   `yaml:"notes,omitempty" json:"notes,omitempty"`
 	CustomFrequencyDays  int      `yaml:"custom_frequency_days,omitempty" json:"custom_frequency_days,omitempty"`
+	ReminderLeadDays     *int     `yaml:"reminder_lead_days,omitempty" json:"reminder_lead_days,omitempty"` // overrides the policy's AttentionDays; nil uses it
 	LastInteractionType  string   `yaml:"last_interaction_type,omitempty" json:"last_interaction_type,omitempty"`
-	RelatedContactLabels []string `yaml:"related_contact_labels,omitempty" json:"related_contact_labels,omitempty"`
+	RelatedContactLabels []string `yaml:"related_contact_labels,omitempty" json:"related_contact_labels,omitempty"` // names for RelatedPeople, by position
+	DeletedAt            string   `yaml:"deleted_at,omitempty" json:"deleted_at,omitempty"`                         // set while the contact is in the trash
+	SchemaVersion        int      `yaml:"schema_version,omitempty" json:"schema_version,omitempty"`                 // frontmatter layout version; unset means 1
+	LegacyUpdatedAt      string   `yaml:"updated_at,omitempty" json:"-"`                                            // pre-acore name for modified, read so `upgrade` can move it
+
+	// Snapshots of the computed fields, written by `sync-computed` for tools
+	// that read the files directly
+	StoredDaysSince     *int   `yaml:"days_since_contact,omitempty" json:"-"`
+	StoredOverdueStatus string `yaml:"overdue_status,omitempty" json:"-"`
+	ComputedAt          string `yaml:"computed_at,omitempty" json:"-"`
 
 	// Runtime/computed fields (not in YAML)
 	Content       string `yaml:"-" json:"-"`
 	DaysSince     int    `yaml:"-" json:"days_since_contact"`
+	Scheduled     bool   `yaml:"-" json:"scheduled,omitempty"` // last_contacted is after today; see IsScheduled
 	OverdueStatus string `yaml:"-" json:"overdue_status,omitempty"`
+	Warmth        int    `yaml:"-" json:"warmth"`
+	Archived      bool   `yaml:"-" json:"archived"`
 }
 
 // Interaction represents a single interaction with a contact
 type Interaction struct {
-	Date    time.Time       `yaml:"date"`
-	Type    InteractionType `yaml:"type"`
-	Summary string          `yaml:"summary,omitempty"`
+	Date    time.Time       `yaml:"date" json:"date"`
+	Type    InteractionType `yaml:"type" json:"type"`
+	Summary string          `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Details string          `yaml:"details,omitempty" json:"details,omitempty"` // indented note block under the entry
+	Minutes int             `yaml:"duration_minutes,omitempty" json:"duration_minutes,omitempty"`
 }
 
-// GetFrequencyDays returns the contact frequency in days
-func (c *Contact) GetFrequencyDays() int {
-	if c.CustomFrequencyDays > 0 {
-		return c.CustomFrequencyDays
+// AllEmails returns the primary email (if set) followed by any additional emails
+func (c *Contact) AllEmails() []LabeledValue {
+	return withPrimary(c.Email, c.Emails)
+}
+
+// AllPhones returns the primary phone (if set) followed by any additional phones
+func (c *Contact) AllPhones() []LabeledValue {
+	return withPrimary(c.Phone, c.Phones)
+}
+
+// AddEmail records an email address. The first address becomes the primary
+// Email; later ones are appended to Emails. Duplicates are ignored.
+func (c *Contact) AddEmail(label, value string) {
+	addLabeled(&c.Email, &c.Emails, label, value)
+}
+
+// AddPhone records a phone number. The first number becomes the primary
+// Phone; later ones are appended to Phones. Duplicates are ignored.
+func (c *Contact) AddPhone(label, value string) {
+	addLabeled(&c.Phone, &c.Phones, label, value)
+}
+
+func withPrimary(primary string, extra []LabeledValue) []LabeledValue {
+	var all []LabeledValue
+	if primary != "" {
+		all = append(all, LabeledValue{Value: primary})
+	}
+	for _, v := range extra {
+		if v.Value != "" && v.Value != primary {
+			all = append(all, v)
+		}
+	}
+	return all
+}
+
+func addLabeled(primary *string, extra *[]LabeledValue, label, value string) {
+	if value == "" {
+		return
+	}
+	if *primary == "" {
+		*primary = value
+		return
 	}
+	if *primary == value {
+		return
+	}
+	for _, v := range *extra {
+		if v.Value == value {
+			return
+		}
+	}
+	*extra = append(*extra, LabeledValue{Label: label, Value: value})
+}
+
+// fieldClear empties, per field name accepted by ClearField, the contact's
+// value for that field. email and phone clear the labeled extras too, so a
+// cleared field counts as missing.
+var fieldClear = map[string]func(c *Contact){
+	"email":       func(c *Contact) { c.Email, c.Emails = "", nil },
+	"phone":       func(c *Contact) { c.Phone, c.Phones = "", nil },
+	"company":     func(c *Contact) { c.Company = "" },
+	"role":        func(c *Contact) { c.Role = "" },
+	"location":    func(c *Contact) { c.Location = "" },
+	"birthday":    func(c *Contact) { c.Birthday = "" },
+	"label":       func(c *Contact) { c.Label = "" },
+	"linkedin":    func(c *Contact) { c.LinkedIn = "" },
+	"twitter":     func(c *Contact) { c.Twitter = "" },
+	"website":     func(c *Contact) { c.Website = "" },
+	"avatar":      func(c *Contact) { c.Avatar = "" },
+	"aliases":     func(c *Contact) { c.Aliases = nil },
+	"frequency":   func(c *Contact) { c.CustomFrequencyDays = 0 },
+	"lead":        func(c *Contact) { c.ReminderLeadDays = nil },
+	"planned_for": func(c *Contact) { c.PlannedFor = "" },
+}
 
-	switch c.RelationshipType {
-	case RelationshipClose, RelationshipFamily:
-		return 30
-	case RelationshipWork:
-		return 60
-	case RelationshipNetwork:
-		return 90
-	default:
-		return 0 // No default for social
+// ClearableFieldNames lists the field names ClearField accepts.
+func ClearableFieldNames() []string {
+	names := make([]string, 0, len(fieldClear))
+	for name := range fieldClear {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
 }
 
-// DaysSinceContact returns days since last contact (not bump)
+// ClearField empties the named field, reporting false for an unknown name.
+func (c *Contact) ClearField(name string) bool {
+	empty, ok := fieldClear[name]
+	if ok {
+		empty(c)
+	}
+	return ok
+}
+
+// GetFrequencyDays returns the contact frequency in days
+func (c *Contact) GetFrequencyDays() int {
+	return activePolicy.FrequencyDays(c)
+}
+
+// DaysSinceContact returns calendar days since last contact (not bump), counted
+// in the local time zone (the configured timezone, if set). It is -1 when the
+// contact was never contacted and negative when last_contacted is in the
+// future; a date tomorrow also gives -1, so use IsScheduled (or the
+// Scheduled field in JSON) to tell the two apart.
 func (c *Contact) DaysSinceContact() int {
 	if c.LastContacted == nil {
 		return -1 // Never contacted
 	}
-	duration := time.Since(*c.LastContacted)
-	days := int(duration.Hours() / 24)
-	if duration < 0 {
-		return days
+	return calendarDays(*c.LastContacted, time.Now())
+}
+
+// IsScheduled reports whether last_contacted is a date after today, e.g. a
+// call entered ahead of time.
+func (c *Contact) IsScheduled() bool {
+	return c.LastContacted != nil && c.DaysSinceContact() < 0
+}
+
+// calendarDays returns the number of midnights between from and to in the
+// local time zone. DST days are 23 or 25 hours long, hence the rounding.
+func calendarDays(from, to time.Time) int {
+	midnight := func(t time.Time) time.Time {
+		y, m, d := t.In(time.Local).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
 	}
-	return days
+	return int(math.Round(midnight(to).Sub(midnight(from)).Hours() / 24))
 }
 
 // IsOverdue returns true if contact is overdue based on frequency
 func (c *Contact) IsOverdue() bool {
-	if c.ContactStyle != StylePeriodic && c.ContactStyle != "" {
-		return false
-	}
-	freq := c.GetFrequencyDays()
-	if freq == 0 {
-		return false
-	}
-	days := c.DaysSinceContact()
-	if days == -1 {
-		return true
-	}
-	return days > freq
+	return activePolicy.IsOverdue(c)
 }
 
 // NeedsAttention returns true if contact needs attention soon
 func (c *Contact) NeedsAttention() bool {
-	if c.ContactStyle != StylePeriodic && c.ContactStyle != "" {
-		return false
-	}
-	freq := c.GetFrequencyDays()
-	if freq == 0 {
-		return false
-	}
-	days := c.DaysSinceContact()
-	if days == -1 {
-		return true
-	}
-	return days > (freq-7) && days <= freq
+	return activePolicy.NeedsAttention(c)
+}
+
+// WarmthScore returns the contact's 0-100 warmth given how many interactions
+// are logged for it
+func (c *Contact) WarmthScore(interactions int) int {
+	return activePolicy.Warmth(c, interactions)
 }
 
 // IsWithinThreshold returns true if contact has been contacted within their expected frequency
 func (c *Contact) IsWithinThreshold() bool {
-	if c.ContactStyle != StylePeriodic && c.ContactStyle != "" {
-		return false
-	}
-	freq := c.GetFrequencyDays()
-	if freq == 0 {
-		return false
-	}
-	days := c.DaysSinceContact()
-	if days == -1 {
-		return false
-	}
-	return days >= 0 && days <= (freq/2)
+	return activePolicy.IsWithinThreshold(c)
 }
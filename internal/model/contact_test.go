@@ -0,0 +1,52 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContactSchedule(t *testing.T) {
+	// daysAgo returns noon local time, days before today (negative: after)
+	daysAgo := func(days int) *time.Time {
+		y, m, d := time.Now().Date()
+		at := time.Date(y, m, d-days, 12, 0, 0, 0, time.Local)
+		return &at
+	}
+
+	tests := []struct {
+		name          string
+		relType       RelationshipType
+		style         ContactStyle
+		lastContacted *time.Time
+		wantDays      int
+		wantScheduled bool
+		wantOverdue   bool
+	}{
+		{"never contacted", RelationshipClose, "", nil, -1, false, true},
+		{"never contacted, no frequency", RelationshipSocial, "", nil, -1, false, false},
+		{"tomorrow", RelationshipClose, "", daysAgo(-1), -1, true, false},
+		{"tomorrow, no frequency", RelationshipSocial, "", daysAgo(-1), -1, true, false},
+		{"next week", RelationshipClose, StylePeriodic, daysAgo(-7), -7, true, false},
+		{"far future", RelationshipClose, "", daysAgo(-400), -400, true, false},
+		{"today", RelationshipClose, "", daysAgo(0), 0, false, false},
+		{"within frequency", RelationshipClose, "", daysAgo(10), 10, false, false},
+		{"on the due date", RelationshipClose, "", daysAgo(30), 30, false, false},
+		{"past frequency", RelationshipClose, "", daysAgo(31), 31, false, true},
+		{"past frequency, ambient", RelationshipClose, StyleAmbient, daysAgo(31), 31, false, false},
+		{"past, no frequency", RelationshipSocial, "", daysAgo(365), 365, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Contact{RelationshipType: tt.relType, ContactStyle: tt.style, LastContacted: tt.lastContacted}
+			if got := c.DaysSinceContact(); got != tt.wantDays {
+				t.Errorf("DaysSinceContact() = %d, want %d", got, tt.wantDays)
+			}
+			if got := c.IsScheduled(); got != tt.wantScheduled {
+				t.Errorf("IsScheduled() = %v, want %v", got, tt.wantScheduled)
+			}
+			if got := c.IsOverdue(); got != tt.wantOverdue {
+				t.Errorf("IsOverdue() = %v, want %v", got, tt.wantOverdue)
+			}
+		})
+	}
+}
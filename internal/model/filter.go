@@ -0,0 +1,193 @@
+package model
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ContactFilter holds the criteria shared by `list` and the TUI. Empty fields
+// don't filter.
+type ContactFilter struct {
+	Type            string
+	State           string
+	Style           string
+	Tag             string
+	Search          string
+	SearchContent   bool   // also match Search against the file body
+	PlannedFor      string // today, YYYY-MM-DD, or any
+	Overdue         bool
+	Engaged         bool // any state other than ok or archived
+	IncludeArchived bool
+	RelatedTask     string    // ULID that must appear in related_tasks
+	RelatedIdea     string    // ULID that must appear in related_ideas
+	RelatedPerson   string    // ULID that must appear in related_people
+	ModifiedSince   time.Time // only contacts modified after this; contacts without a modified time always match
+	Missing         []string  // field names (see MissingFieldNames) the contact must all lack
+}
+
+// fieldPresent reports, per field name accepted by ContactFilter.Missing,
+// whether a contact has a value for that field.
+var fieldPresent = map[string]func(c *Contact) bool{
+	"email":          func(c *Contact) bool { return len(c.AllEmails()) > 0 },
+	"phone":          func(c *Contact) bool { return len(c.AllPhones()) > 0 },
+	"company":        func(c *Contact) bool { return c.Company != "" },
+	"role":           func(c *Contact) bool { return c.Role != "" },
+	"location":       func(c *Contact) bool { return c.Location != "" },
+	"birthday":       func(c *Contact) bool { return c.Birthday != "" },
+	"label":          func(c *Contact) bool { return c.Label != "" },
+	"linkedin":       func(c *Contact) bool { return c.LinkedIn != "" },
+	"twitter":        func(c *Contact) bool { return c.Twitter != "" },
+	"website":        func(c *Contact) bool { return c.Website != "" },
+	"avatar":         func(c *Contact) bool { return c.Avatar != "" },
+	"aliases":        func(c *Contact) bool { return len(c.Aliases) > 0 },
+	"frequency":      func(c *Contact) bool { return c.GetFrequencyDays() > 0 },
+	"last_contacted": func(c *Contact) bool { return c.LastContacted != nil },
+	"related_people": func(c *Contact) bool { return len(c.RelatedPeople) > 0 },
+}
+
+// MissingFieldNames lists the field names ContactFilter.Missing accepts.
+func MissingFieldNames() []string {
+	names := make([]string, 0, len(fieldPresent))
+	for name := range fieldPresent {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidMissingField reports whether name can be used in ContactFilter.Missing.
+func ValidMissingField(name string) bool {
+	return slices.Contains(MissingFieldNames(), name)
+}
+
+// FilterContacts returns the contacts matching f, in their original order.
+func FilterContacts(contacts []Contact, f ContactFilter) []Contact {
+	filtered := []Contact{}
+	for _, c := range contacts {
+		if f.Matches(&c) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// Matches reports whether a single contact passes the filter.
+func (f ContactFilter) Matches(c *Contact) bool {
+	if !f.IncludeArchived && c.State == string(StateArchived) {
+		return false
+	}
+	if f.Type != "" && string(c.RelationshipType) != f.Type {
+		return false
+	}
+	if f.State != "" && c.State != f.State {
+		return false
+	}
+	if f.Engaged && (c.State == "" || c.State == string(StateOk) || c.State == string(StateArchived)) {
+		return false
+	}
+	if f.Style != "" && string(c.ContactStyle) != f.Style {
+		return false
+	}
+	if f.Overdue && !c.IsOverdue() {
+		return false
+	}
+	if f.Tag != "" && !c.HasTag(f.Tag) {
+		return false
+	}
+	if f.Search != "" && !MatchesSearch(c, f.Search, f.SearchContent) {
+		return false
+	}
+	if f.RelatedTask != "" && !containsID(c.RelatedTasks, f.RelatedTask) {
+		return false
+	}
+	if f.RelatedIdea != "" && !containsID(c.RelatedIdeas, f.RelatedIdea) {
+		return false
+	}
+	if f.RelatedPerson != "" && !containsID(c.RelatedPeople, f.RelatedPerson) {
+		return false
+	}
+	if !f.ModifiedSince.IsZero() {
+		if modified, err := time.Parse(time.RFC3339, c.Modified); err == nil && !modified.After(f.ModifiedSince) {
+			return false
+		}
+	}
+	for _, field := range f.Missing {
+		if present, ok := fieldPresent[field]; ok && present(c) {
+			return false
+		}
+	}
+	if f.PlannedFor != "" {
+		switch strings.ToLower(f.PlannedFor) {
+		case "any":
+			if c.PlannedFor == "" {
+				return false
+			}
+		case "today":
+			if c.PlannedFor != time.Now().Format("2006-01-02") {
+				return false
+			}
+		default:
+			if c.PlannedFor != f.PlannedFor {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MatchesSearch reports whether query appears (case-insensitively) in the
+// contact's name, aliases, company, role, label, emails or tags. A query starting with
+// "#" matches tags only. With includeContent the file body is searched too.
+func MatchesSearch(c *Contact, query string, includeContent bool) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+
+	if tag, ok := strings.CutPrefix(query, "#"); ok {
+		for _, t := range c.Tags {
+			if strings.HasPrefix(strings.ToLower(t), tag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, field := range []string{c.Title, c.Company, c.Role, c.Label} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	for _, a := range c.Aliases {
+		if strings.Contains(strings.ToLower(a), query) {
+			return true
+		}
+	}
+	for _, e := range c.AllEmails() {
+		if strings.Contains(strings.ToLower(e.Value), query) {
+			return true
+		}
+	}
+	for _, t := range c.Tags {
+		if strings.Contains(strings.ToLower(t), query) {
+			return true
+		}
+	}
+	if includeContent {
+		return strings.Contains(strings.ToLower(c.Content), query)
+	}
+	return false
+}
+
+// containsID reports whether ids includes id, ignoring case (ULIDs are
+// case-insensitive).
+func containsID(ids []string, id string) bool {
+	for _, x := range ids {
+		if strings.EqualFold(x, id) {
+			return true
+		}
+	}
+	return false
+}
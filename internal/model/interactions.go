@@ -0,0 +1,107 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// IsContact reports whether the interaction type represents actually reaching
+// the person. Bumps and notes are reviews, not contact, and reclassifications
+// are bookkeeping.
+func (t InteractionType) IsContact() bool {
+	return t != InteractionBump && t != InteractionNote && t != InteractionReclassified
+}
+
+// HasDuration reports whether a length makes sense for the interaction type.
+// Only calls, meetings and social get-togethers take up a block of time.
+func (t InteractionType) HasDuration() bool {
+	return t == InteractionCall || t == InteractionMeeting || t == InteractionSocial
+}
+
+// Cadence summarizes the spacing of a contact's interactions
+type Cadence struct {
+	Interactions        int        `json:"interactions"`
+	AverageIntervalDays float64    `json:"average_interval_days"`
+	LongestGapDays      int        `json:"longest_gap_days"`
+	LongestGapStart     *time.Time `json:"longest_gap_start,omitempty"`
+	LongestGapEnd       *time.Time `json:"longest_gap_end,omitempty"`
+	FrequencyDays       int        `json:"frequency_days"`
+	TotalMinutes        int        `json:"total_minutes"`
+	Comparison          string     `json:"comparison"` // faster, slower, on-pace, or unknown
+}
+
+// cadenceTolerance is how far (as a fraction of the frequency) the average
+// interval may drift before it counts as faster or slower.
+const cadenceTolerance = 0.1
+
+// AnalyzeCadence computes interval statistics over the contact-type interactions
+// and compares the average interval to frequencyDays (0 means no frequency).
+func AnalyzeCadence(interactions []Interaction, frequencyDays int) Cadence {
+	var dates []time.Time
+	minutes := 0
+	for _, i := range interactions {
+		if i.Type.IsContact() {
+			dates = append(dates, i.Date)
+			minutes += i.Minutes
+		}
+	}
+	sort.Slice(dates, func(a, b int) bool { return dates[a].Before(dates[b]) })
+
+	c := Cadence{Interactions: len(dates), FrequencyDays: frequencyDays, TotalMinutes: minutes, Comparison: "unknown"}
+	if len(dates) < 2 {
+		return c
+	}
+
+	total := 0
+	for i := 1; i < len(dates); i++ {
+		gap := int(dates[i].Sub(dates[i-1]).Hours() / 24)
+		total += gap
+		if c.LongestGapStart == nil || gap > c.LongestGapDays {
+			start, end := dates[i-1], dates[i]
+			c.LongestGapDays = gap
+			c.LongestGapStart = &start
+			c.LongestGapEnd = &end
+		}
+	}
+	c.AverageIntervalDays = float64(total) / float64(len(dates)-1)
+
+	if frequencyDays > 0 {
+		freq := float64(frequencyDays)
+		switch {
+		case c.AverageIntervalDays < freq*(1-cadenceTolerance):
+			c.Comparison = "faster"
+		case c.AverageIntervalDays > freq*(1+cadenceTolerance):
+			c.Comparison = "slower"
+		default:
+			c.Comparison = "on-pace"
+		}
+	}
+	return c
+}
+
+// InteractionSummary is a quick overview of a contact's interaction log
+type InteractionSummary struct {
+	Total        int            `json:"total"`
+	First        *time.Time     `json:"first,omitempty"`
+	Last         *time.Time     `json:"last,omitempty"`
+	ByType       map[string]int `json:"by_type"`
+	TotalMinutes int            `json:"total_minutes,omitempty"`
+}
+
+// SummarizeInteractions counts all logged interactions (bumps and notes
+// included) by type, finds the first and last dates and totals logged time.
+func SummarizeInteractions(interactions []Interaction) InteractionSummary {
+	s := InteractionSummary{Total: len(interactions), ByType: map[string]int{}}
+	for _, i := range interactions {
+		s.ByType[string(i.Type)]++
+		s.TotalMinutes += i.Minutes
+		d := i.Date
+		if s.First == nil || d.Before(*s.First) {
+			s.First = &d
+		}
+		if s.Last == nil || d.After(*s.Last) {
+			s.Last = &d
+		}
+	}
+	return s
+}
@@ -0,0 +1,174 @@
+package model
+
+import "math"
+
+// Policy holds the rules that decide when a contact is due: default
+// frequencies per relationship type and the attention/good windows, plus
+// the weights behind the warmth score.
+type Policy struct {
+	// Frequencies maps relationship types to their default contact frequency
+	// in days. Types without an entry have no default.
+	Frequencies map[RelationshipType]int
+	// AttentionDays is how many days before a contact is due it needs attention.
+	AttentionDays int
+	// GoodRatio is the fraction of the frequency within which a contact
+	// counts as recently contacted.
+	GoodRatio float64
+	// MinFrequencyDays and MaxFrequencyDays bound the effective frequency,
+	// catching typos like a 3000-day custom frequency; 0 means no bound.
+	MinFrequencyDays int
+	MaxFrequencyDays int
+
+	// WarmthHalfLife is the fraction of the frequency after which the recency
+	// part of warmth has halved (1.0: half-warm on the due date).
+	WarmthHalfLife float64
+	// WarmthFallbackDays stands in for the frequency of contacts without one.
+	WarmthFallbackDays int
+	// WarmthRecencyWeight is the share of warmth from recency; the rest comes
+	// from the number of logged interactions.
+	WarmthRecencyWeight float64
+	// WarmthSaturation is the interaction count at which the history part
+	// of warmth is full.
+	WarmthSaturation int
+}
+
+// DefaultPolicy returns the built-in frequencies and windows.
+func DefaultPolicy() Policy {
+	return Policy{
+		Frequencies: map[RelationshipType]int{
+			RelationshipClose:   30,
+			RelationshipFamily:  30,
+			RelationshipWork:    60,
+			RelationshipNetwork: 90,
+		},
+		AttentionDays:       7,
+		GoodRatio:           0.5,
+		WarmthHalfLife:      1.0,
+		WarmthFallbackDays:  90,
+		WarmthRecencyWeight: 0.8,
+		WarmthSaturation:    10,
+	}
+}
+
+// activePolicy is consulted by the Contact convenience methods.
+var activePolicy = DefaultPolicy()
+
+// SetPolicy replaces the policy used by the Contact health methods.
+func SetPolicy(p Policy) {
+	activePolicy = p
+}
+
+// CurrentPolicy returns the policy used by the Contact health methods.
+func CurrentPolicy() Policy {
+	return activePolicy
+}
+
+// FrequencyDays returns the contact frequency in days, preferring the
+// contact's custom frequency, clamped to the policy's bounds. Returns 0 when
+// there is none.
+func (p Policy) FrequencyDays(c *Contact) int {
+	freq := p.Frequencies[c.RelationshipType]
+	if c.CustomFrequencyDays > 0 {
+		freq = c.CustomFrequencyDays
+	}
+	if freq == 0 {
+		return 0
+	}
+	if p.MinFrequencyDays > 0 && freq < p.MinFrequencyDays {
+		return p.MinFrequencyDays
+	}
+	if p.MaxFrequencyDays > 0 && freq > p.MaxFrequencyDays {
+		return p.MaxFrequencyDays
+	}
+	return freq
+}
+
+// FrequencyInRange reports whether days lies within the frequency bounds.
+func (p Policy) FrequencyInRange(days int) bool {
+	return (p.MinFrequencyDays == 0 || days >= p.MinFrequencyDays) &&
+		(p.MaxFrequencyDays == 0 || days <= p.MaxFrequencyDays)
+}
+
+// tracked returns the frequency for periodic contacts, or 0 if the contact
+// isn't tracked against one.
+func (p Policy) tracked(c *Contact) int {
+	if c.ContactStyle != StylePeriodic && c.ContactStyle != "" {
+		return 0
+	}
+	return p.FrequencyDays(c)
+}
+
+// IsOverdue returns true if the contact is past its frequency (or never
+// contacted). A contact scheduled in the future is never overdue.
+func (p Policy) IsOverdue(c *Contact) bool {
+	freq := p.tracked(c)
+	if freq == 0 {
+		return false
+	}
+	if c.LastContacted == nil {
+		return true
+	}
+	return c.DaysSinceContact() > freq
+}
+
+// LeadDays returns how many days before it's due the contact needs
+// attention: its own reminder_lead_days if set, otherwise AttentionDays.
+func (p Policy) LeadDays(c *Contact) int {
+	if c.ReminderLeadDays != nil {
+		return *c.ReminderLeadDays
+	}
+	return p.AttentionDays
+}
+
+// NeedsAttention returns true if the contact is due within its lead days
+func (p Policy) NeedsAttention(c *Contact) bool {
+	freq := p.tracked(c)
+	if freq == 0 {
+		return false
+	}
+	if c.LastContacted == nil {
+		return true
+	}
+	days := c.DaysSinceContact()
+	if days < 0 {
+		return false // scheduled
+	}
+	return days > (freq-p.LeadDays(c)) && days <= freq
+}
+
+// IsWithinThreshold returns true if the contact was reached within GoodRatio of its frequency
+func (p Policy) IsWithinThreshold(c *Contact) bool {
+	freq := p.tracked(c)
+	if freq == 0 {
+		return false
+	}
+	days := c.DaysSinceContact()
+	return c.LastContacted != nil && days >= 0 && days <= int(float64(freq)*p.GoodRatio)
+}
+
+// Warmth scores relationship health from 0 (cold) to 100. Recency decays
+// exponentially with days since contact, halving every WarmthHalfLife
+// frequencies; interactions adds up to (1-WarmthRecencyWeight) for a long
+// history. Never-contacted contacts score 0; a contact scheduled in the
+// future counts as contacted today.
+func (p Policy) Warmth(c *Contact, interactions int) int {
+	if c.LastContacted == nil {
+		return 0
+	}
+	days := max(c.DaysSinceContact(), 0)
+	freq := p.FrequencyDays(c)
+	if freq == 0 {
+		freq = p.WarmthFallbackDays
+	}
+	halfLife := float64(freq) * p.WarmthHalfLife
+	recency := 1.0
+	if halfLife > 0 {
+		recency = math.Pow(0.5, float64(days)/halfLife)
+	}
+	history := 1.0
+	if p.WarmthSaturation > 0 {
+		history = math.Min(1, float64(interactions)/float64(p.WarmthSaturation))
+	}
+	score := p.WarmthRecencyWeight*recency + (1-p.WarmthRecencyWeight)*history
+	return int(math.Round(100 * score))
+}
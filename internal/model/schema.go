@@ -0,0 +1,116 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// schemaEnums gives the allowed values of enum-like fields, by JSON name.
+// Typed fields (RelationshipType, ContactStyle) are found by type as well, so
+// they stay covered if renamed.
+var schemaEnums = map[string]func() []string{
+	"relationship_type":     func() []string { return enumStrings(AllRelationshipTypes()) },
+	"contact_style":         func() []string { return enumStrings(ContactStyles) },
+	"state":                 func() []string { return enumStrings(ContactStates) },
+	"last_interaction_type": func() []string { return enumStrings(InteractionTypes) },
+}
+
+var schemaEnumTypes = map[reflect.Type]string{
+	reflect.TypeOf(RelationshipType("")): "relationship_type",
+	reflect.TypeOf(ContactStyle("")):     "contact_style",
+	reflect.TypeOf(ContactState("")):     "state",
+	reflect.TypeOf(InteractionType("")):  "last_interaction_type",
+}
+
+// ContactJSONSchema returns a JSON Schema (draft 2020-12) for the JSON form of
+// Contact, built by reflecting over the struct so it follows the model.
+// Computed fields that aren't stored in the file are marked readOnly.
+func ContactJSONSchema() map[string]interface{} {
+	schema := structSchema(reflect.TypeOf(Contact{}))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "apeople contact"
+	schema["required"] = []string{"title"}
+	return schema
+}
+
+// structSchema describes a struct's JSON fields, flattening embedded structs
+// the way encoding/json does.
+func structSchema(t reflect.Type) map[string]interface{} {
+	props := map[string]interface{}{}
+	var addFields func(t reflect.Type)
+	addFields = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+				addFields(f.Type)
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+
+			prop := typeSchema(f.Type)
+			if enum, ok := schemaEnums[name]; ok {
+				prop["enum"] = enum()
+			}
+			if f.Tag.Get("yaml") == "-" {
+				prop["readOnly"] = true
+			}
+			props[name] = prop
+		}
+	}
+	addFields(t)
+	return map[string]interface{}{"type": "object", "properties": props}
+}
+
+// typeSchema describes a single Go type.
+func typeSchema(t reflect.Type) map[string]interface{} {
+	nullable := false
+	if t.Kind() == reflect.Pointer {
+		t, nullable = t.Elem(), true
+	}
+
+	var s map[string]interface{}
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		s = map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.String:
+		s = map[string]interface{}{"type": "string"}
+		if name, ok := schemaEnumTypes[t]; ok {
+			s["enum"] = schemaEnums[name]()
+		}
+	case t.Kind() == reflect.Bool:
+		s = map[string]interface{}{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		s = map[string]interface{}{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		s = map[string]interface{}{"type": "number"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		s = map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case t.Kind() == reflect.Map:
+		s = map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case t.Kind() == reflect.Struct:
+		s = structSchema(t)
+	default:
+		s = map[string]interface{}{}
+	}
+	if nullable {
+		s["type"] = []interface{}{s["type"], "null"}
+	}
+	return s
+}
+
+// enumStrings converts a list of string-typed constants to plain strings.
+func enumStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package model
+
+import "strings"
+
+// NormalizeLinks rewrites the LinkedIn, Twitter and Website fields to full
+// https URLs. Values that already have a scheme are left alone.
+func (c *Contact) NormalizeLinks() {
+	c.LinkedIn = NormalizeLinkedIn(c.LinkedIn)
+	c.Twitter = NormalizeTwitter(c.Twitter)
+	c.Website = NormalizeWebsite(c.Website)
+}
+
+// NormalizeLinkedIn turns "@name", "name" or "linkedin.com/in/name" into
+// "https://www.linkedin.com/in/name".
+func NormalizeLinkedIn(value string) string {
+	return normalizeProfile(value, "linkedin.com", "https://www.linkedin.com/in/")
+}
+
+// NormalizeTwitter turns "@handle", "handle" or "twitter.com/handle" into
+// "https://x.com/handle".
+func NormalizeTwitter(value string) string {
+	value = strings.TrimSpace(value)
+	for _, host := range []string{"twitter.com/", "www.twitter.com/", "mobile.twitter.com/"} {
+		if rest, ok := strings.CutPrefix(value, host); ok {
+			value = "x.com/" + rest
+		}
+	}
+	return normalizeProfile(value, "x.com", "https://x.com/")
+}
+
+// NormalizeWebsite adds https:// to a bare domain.
+func NormalizeWebsite(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || hasScheme(value) {
+		return value
+	}
+	return "https://" + value
+}
+
+// normalizeProfile handles a social profile field: full URLs are kept, a
+// scheme-less URL on host gets https://, and a bare handle is appended to
+// profileURL.
+func normalizeProfile(value, host, profileURL string) string {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "" || hasScheme(value):
+		return value
+	case strings.HasPrefix(value, host+"/") || strings.HasPrefix(value, "www."+host+"/"):
+		return "https://" + value
+	case strings.ContainsAny(value, "./"):
+		return NormalizeWebsite(value) // some other site; don't guess
+	default:
+		return profileURL + strings.TrimPrefix(value, "@")
+	}
+}
+
+// hasScheme reports whether value starts with a URL scheme such as https://.
+func hasScheme(value string) bool {
+	scheme, _, ok := strings.Cut(value, "://")
+	return ok && scheme != "" && !strings.ContainsAny(scheme, "./")
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// contactKeyOrder is the order frontmatter keys are written in. Keeping it fixed
+// (rather than relying on struct and embedding order) means a field appearing or
+// disappearing only adds or removes its own line in a diff.
+var contactKeyOrder = []string{
+	// Identity
+	"id", "title", "aliases", "index_id", "type", "tags", "created", "modified", "planned_for",
+	// Contact details
+	"email", "emails", "phone", "phones", "company", "role", "location",
+	"birthday", "linkedin", "twitter", "website", "avatar",
+	// Relationship
+	"relationship_type", "contact_style", "state", "label", "custom_frequency_days", "reminder_lead_days",
+	// Tracking
+	"last_contacted", "last_interaction_type", "last_bump_date", "bump_count",
+	// Relations
+	"related_people", "related_contact_labels", "related_tasks", "related_ideas",
+	"notes", "deleted_at", "schema_version",
+	// Computed snapshots
+	"days_since_contact", "overdue_status", "computed_at",
+}
+
+// MarshalYAML writes contact frontmatter in contactKeyOrder. Keys not in the
+// list (e.g. new acore fields) follow in alphabetical order.
+func (c Contact) MarshalYAML() (interface{}, error) {
+	type plain Contact // drops this method to avoid recursion
+	var node yaml.Node
+	if err := node.Encode(plain(c)); err != nil {
+		return nil, err
+	}
+	if node.Kind != yaml.MappingNode {
+		return &node, nil
+	}
+
+	rank := make(map[string]int, len(contactKeyOrder))
+	for i, k := range contactKeyOrder {
+		rank[k] = i
+	}
+
+	type pair struct{ key, value *yaml.Node }
+	pairs := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, pair{node.Content[i], node.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		ri, iKnown := rank[pairs[i].key.Value]
+		rj, jKnown := rank[pairs[j].key.Value]
+		switch {
+		case iKnown && jKnown:
+			return ri < rj
+		case iKnown != jKnown:
+			return iKnown
+		default:
+			return pairs[i].key.Value < pairs[j].key.Value
+		}
+	})
+
+	node.Content = node.Content[:0]
+	for _, p := range pairs {
+		node.Content = append(node.Content, p.key, p.value)
+	}
+	return &node, nil
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// fullContact returns a contact with every frontmatter field set, so the
+// golden file covers the whole of contactKeyOrder.
+func fullContact() Contact {
+	lastContacted := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	lastBump := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+	days := 12
+	lead := 3
+
+	c := Contact{
+		Email:                "ada@example.com",
+		Phone:                "+1 555 0100",
+		Emails:               []LabeledValue{{Label: "work", Value: "ada@work.example"}},
+		Phones:               []LabeledValue{{Label: "mobile", Value: "+1 555 0101"}},
+		RelationshipType:     RelationshipClose,
+		State:                string(StateFollowup),
+		Label:                "mentor",
+		ContactStyle:         StylePeriodic,
+		LastContacted:        &lastContacted,
+		LastBumpDate:         &lastBump,
+		BumpCount:            4,
+		Aliases:              []string{"Countess"},
+		Company:              "Analytical Engines",
+		Role:                 "Programmer",
+		Location:             "London",
+		Birthday:             "12-10",
+		LinkedIn:             "ada-lovelace",
+		Twitter:              "ada",
+		Website:              "https://ada.example",
+		Avatar:               "avatars/ada.jpg",
+		Notes:                "Met at the Difference Engine demo",
+		CustomFrequencyDays:  21,
+		ReminderLeadDays:     &lead,
+		LastInteractionType:  string(InteractionCall),
+		RelatedContactLabels: []string{"colleague"},
+		DeletedAt:            "2026-03-02T00:00:00Z",
+		SchemaVersion:        2,
+		LegacyUpdatedAt:      "2026-01-01T00:00:00Z",
+		StoredDaysSince:      &days,
+		StoredOverdueStatus:  "ok",
+		ComputedAt:           "2026-03-13T00:00:00Z",
+
+		// Runtime fields must not appear in the output
+		Content:       "## Notes\n",
+		DaysSince:     12,
+		OverdueStatus: "ok",
+		Warmth:        80,
+		Archived:      true,
+	}
+	c.ID = "01HZX3KQ7B6YV0M5D8E2C4F9TA"
+	c.Title = "Ada Lovelace"
+	c.IndexID = 7
+	c.Type = "contact"
+	c.Tags = []string{"contact", "math"}
+	c.Created = "2025-12-10T08:00:00Z"
+	c.Modified = "2026-03-01T10:00:00Z"
+	c.PlannedFor = "2026-04-01"
+	c.RelatedPeople = []string{"01HZX3KQ7B6YV0M5D8E2C4F9TB"}
+	c.RelatedTasks = []string{"01HZX3KQ7B6YV0M5D8E2C4F9TC"}
+	c.RelatedIdeas = []string{"01HZX3KQ7B6YV0M5D8E2C4F9TD"}
+	c.FilePath = "/contacts/ada.md"
+	return c
+}
+
+func TestMarshalYAMLGolden(t *testing.T) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(fullContact()); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	got := buf.Bytes()
+
+	golden := filepath.Join("testdata", "contact.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("frontmatter differs from %s (run with -update if the change is intended)\ngot:\n%s\nwant:\n%s", golden, got, want)
+	}
+}
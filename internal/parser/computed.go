@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// overdueStatus returns "overdue", "attention", "good", "scheduled" (last
+// contacted in the future) or "" for a contact.
+func overdueStatus(c *model.Contact) string {
+	switch {
+	case c.IsScheduled():
+		return "scheduled"
+	case c.IsOverdue():
+		return "overdue"
+	case c.NeedsAttention():
+		return "attention"
+	case c.IsWithinThreshold():
+		return "good"
+	}
+	return ""
+}
+
+// StoreComputedFields copies the current days-since-contact and overdue status
+// into the contact's persisted snapshot fields, stamped with today's date.
+// Never-contacted contacts get no days_since_contact; scheduled ones get a
+// negative one.
+func StoreComputedFields(c *model.Contact) {
+	c.StoredDaysSince = nil
+	if c.LastContacted != nil {
+		days := c.DaysSinceContact()
+		c.StoredDaysSince = &days
+	}
+	c.StoredOverdueStatus = overdueStatus(c)
+	c.ComputedAt = time.Now().Format("2006-01-02")
+}
+
+// ClearComputedFields removes the persisted snapshot fields.
+func ClearComputedFields(c *model.Contact) {
+	c.StoredDaysSince = nil
+	c.StoredOverdueStatus = ""
+	c.ComputedAt = ""
+}
+
+// SaveComputedFields writes a contact whose snapshot fields were refreshed or
+// cleared. Unlike SaveContactFile it leaves modified alone, since nothing the
+// user edited changed.
+func SaveComputedFields(c model.Contact) error {
+	if c.FilePath == "" {
+		return fmt.Errorf("contact has no file path")
+	}
+	return writeContactFile(c)
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mph-llm-experiments/acore"
+)
+
+var (
+	countersMu sync.Mutex
+	counters   = map[string]*acore.IndexCounter{}
+)
+
+// IndexCounter returns the index ID counter for dir. Counters are cached per
+// absolute directory, so different --dir values in one process (or a
+// long-running agent) each get their own.
+func IndexCounter(dir string) (*acore.IndexCounter, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve contacts directory: %w", err)
+	}
+
+	countersMu.Lock()
+	defer countersMu.Unlock()
+
+	if c, ok := counters[abs]; ok {
+		return c, nil
+	}
+	c, err := acore.NewIndexCounter(acore.NewLocalStore(abs), "apeople")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ID counter: %w", err)
+	}
+	counters[abs] = c
+	return c, nil
+}
+
+// ResetSingleton drops all cached counters, e.g. between tests that reuse a
+// directory path. The name dates from when there was a single counter.
+func ResetSingleton() {
+	countersMu.Lock()
+	defer countersMu.Unlock()
+	counters = map[string]*acore.IndexCounter{}
+}
+
+// dryRunStore reads through to the contacts directory but keeps writes in
+// memory, so acore code run against it leaves the disk untouched.
+type dryRunStore struct {
+	acore.Store
+	written map[string][]byte
+}
+
+func (s *dryRunStore) Read(name string) ([]byte, error) {
+	if data, ok := s.written[name]; ok {
+		return data, nil
+	}
+	return s.Store.Read(name)
+}
+
+func (s *dryRunStore) Write(name string, data []byte) error {
+	s.written[name] = data
+	return nil
+}
+
+// PeekIndexID returns the ID the counter for dir would assign next, without
+// advancing it or taking the lock. acore can only read the counter by
+// advancing it, so this advances a throwaway counter whose writes never
+// reach the disk; acore alone knows the counter's file and format.
+func PeekIndexID(dir string) (int, error) {
+	store := &dryRunStore{Store: acore.NewLocalStore(dir), written: map[string][]byte{}}
+	counter, err := acore.NewIndexCounter(store, "apeople")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ID counter: %w", err)
+	}
+	next, err := counter.Next()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read ID counter: %w", err)
+	}
+	return next, nil
+}
+
+// counterLockFile sits next to the contacts and is flocked around every counter
+// read-modify-write, so concurrent apeople processes hand out distinct IDs.
+const counterLockFile = ".apeople-counter.lock"
+
+// LockCounter takes an exclusive lock on the index counter for dir, blocking
+// until any other holder (in this or another process) releases it. Call the
+// returned function to unlock. The lock is not reentrant.
+func LockCounter(dir string) (func(), error) {
+	f, err := os.OpenFile(filepath.Join(dir, counterLockFile), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open counter lock: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock counter: %w", err)
+	}
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// NextIndexID returns the next index ID for dir while holding the counter lock.
+func NextIndexID(dir string) (int, error) {
+	unlock, err := LockCounter(dir)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	counter, err := IndexCounter(dir)
+	if err != nil {
+		return 0, err
+	}
+	id, err := counter.Next()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next ID: %w", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestNextIndexIDConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	const n = 20
+
+	ids := make([]int, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], errs[i] = NextIndexID(dir)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("NextIndexID: %v", err)
+		}
+	}
+	sort.Ints(ids)
+	for i, id := range ids {
+		if id != i+1 {
+			t.Fatalf("IDs = %v, want 1 through %d with no gaps or repeats", ids, n)
+		}
+	}
+
+	next, err := PeekIndexID(dir)
+	if err != nil {
+		t.Fatalf("PeekIndexID: %v", err)
+	}
+	if next != n+1 {
+		t.Errorf("counter = %d, want %d", next, n+1)
+	}
+}
+
+func TestIndexCounterPerDirectory(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+	for want := 1; want <= 3; want++ {
+		if id, err := NextIndexID(a); err != nil || id != want {
+			t.Fatalf("NextIndexID(a) = %d, %v; want %d", id, err, want)
+		}
+	}
+	if id, err := NextIndexID(b); err != nil || id != 1 {
+		t.Errorf("NextIndexID(b) = %d, %v; want 1, independent of a", id, err)
+	}
+}
+
+func TestResetSingletonDropsCache(t *testing.T) {
+	dir := t.TempDir()
+	before, err := IndexCounter(dir)
+	if err != nil {
+		t.Fatalf("IndexCounter: %v", err)
+	}
+	if again, _ := IndexCounter(dir); again != before {
+		t.Fatal("IndexCounter returned a new counter without a reset")
+	}
+
+	ResetSingleton()
+	after, err := IndexCounter(dir)
+	if err != nil {
+		t.Fatalf("IndexCounter: %v", err)
+	}
+	if after == before {
+		t.Error("IndexCounter returned the cached counter after ResetSingleton")
+	}
+}
+
+// snapshotDir returns the name and content of every file in dir.
+func snapshotDir(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[e.Name()] = string(data)
+	}
+	return files
+}
+
+// PeekIndexID must agree with what acore's counter really hands out next,
+// whatever file and format acore keeps it in, and must not write anything.
+func TestPeekIndexIDMatchesNext(t *testing.T) {
+	for _, advance := range []int{0, 1, 5} {
+		dir := t.TempDir()
+		advanceCounter(t, dir, advance)
+		before := snapshotDir(t, dir)
+
+		peeked, err := PeekIndexID(dir)
+		if err != nil {
+			t.Fatalf("PeekIndexID: %v", err)
+		}
+		if again, _ := PeekIndexID(dir); again != peeked {
+			t.Errorf("after %d IDs: second peek = %d, first = %d", advance, again, peeked)
+		}
+		if after := snapshotDir(t, dir); !maps.Equal(before, after) {
+			t.Errorf("after %d IDs: PeekIndexID changed the directory: %v -> %v", advance, before, after)
+		}
+
+		next, err := NextIndexID(dir)
+		if err != nil {
+			t.Fatalf("NextIndexID: %v", err)
+		}
+		if peeked != next || next != advance+1 {
+			t.Errorf("after %d IDs: peeked %d, counter handed out %d, want %d", advance, peeked, next, advance+1)
+		}
+	}
+}
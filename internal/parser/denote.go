@@ -4,20 +4,49 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mph-llm-experiments/acore"
 	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/oklog/ulid/v2"
 )
 
+// contactTag is the Denote filename keyword (and first tag) marking contact files.
+var contactTag = "contact"
+
+// SetContactTag changes the marker tag, e.g. to coexist with a vault that uses
+// "person". An empty tag restores the default.
+func SetContactTag(tag string) {
+	if tag == "" {
+		tag = "contact"
+	}
+	contactTag = tag
+}
+
+// ContactTag returns the marker tag used in filenames and tags.
+func ContactTag() string {
+	return contactTag
+}
+
 // ParseContactFile parses an acore-format contact file
 func ParseContactFile(path string) (model.Contact, error) {
 	var contact model.Contact
-	store := acore.NewLocalStore(filepath.Dir(path))
-	name := filepath.Base(path)
-	content, err := acore.ReadFile(store, name, &contact)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return model.Contact{}, fmt.Errorf("error parsing contact file: %w", err)
+	}
+
+	var content string
+	if detectFrontmatter(data) == FormatYAML {
+		store := acore.NewLocalStore(filepath.Dir(path))
+		content, err = acore.ReadFile(store, filepath.Base(path), &contact)
+	} else {
+		content, err = readAltFrontmatter(data, &contact)
+	}
 	if err != nil {
 		return model.Contact{}, fmt.Errorf("error parsing contact file: %w", err)
 	}
@@ -34,30 +63,74 @@ func ParseContactFile(path string) (model.Contact, error) {
 		}
 	}
 
+	// Backfill a missing created timestamp from the identifier so a re-save
+	// doesn't leave the contact without one
+	if contact.Created == "" {
+		contact.Created = createdFromIdentifier(contact.ID)
+	}
+	// Schema 1 files may carry updated_at instead of modified
+	if contact.Modified == "" && contact.LegacyUpdatedAt != "" {
+		contact.Modified = normalizeTimestamp(contact.LegacyUpdatedAt)
+	}
+
 	// Initialize relation slices (ensures JSON outputs [] not null)
 	contact.EnsureSlices()
 
 	// Compute runtime fields
 	contact.DaysSince = contact.DaysSinceContact()
-	if contact.IsOverdue() {
-		contact.OverdueStatus = "overdue"
-	} else if contact.NeedsAttention() {
-		contact.OverdueStatus = "attention"
-	} else if contact.IsWithinThreshold() {
-		contact.OverdueStatus = "good"
+	contact.Scheduled = contact.IsScheduled()
+	contact.Archived = contact.State == "archived"
+	contact.OverdueStatus = overdueStatus(&contact)
+	interactions := 0
+	for _, in := range ParseInteractionLog(content) {
+		if in.Type.IsContact() {
+			interactions++
+		}
 	}
+	contact.Warmth = contact.WarmthScore(interactions)
 
 	return contact, nil
 }
 
+// createdFromIdentifier derives an RFC3339 creation time from a ULID or a legacy
+// Denote identifier (20060102T150405). Returns "" if the identifier has neither.
+func createdFromIdentifier(id string) string {
+	if u, err := ulid.ParseStrict(id); err == nil {
+		return ulid.Time(u.Time()).UTC().Format(time.RFC3339)
+	}
+	if t, err := time.ParseInLocation("20060102T150405", id, time.Local); err == nil {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
 // SaveContactFile saves a contact to an acore-format file
 func SaveContactFile(contact model.Contact) error {
 	if contact.FilePath == "" {
 		return fmt.Errorf("contact has no file path")
 	}
 
-	// Update modified timestamp
+	// Update modified timestamp; created is only filled in when missing,
+	// never replaced
 	contact.Modified = acore.Now()
+	if contact.ComputedAt != "" {
+		// Keep already-persisted snapshots in step with the edit
+		StoreComputedFields(&contact)
+	}
+	return writeContactFile(contact)
+}
+
+// writeContactFile writes a contact as-is, without touching modified.
+func writeContactFile(contact model.Contact) error {
+	contact.NormalizeLinks()
+	FoldNotes(&contact)
+	if contact.Created == "" {
+		contact.Created = createdFromIdentifier(contact.ID)
+	}
+
+	if frontmatterFormat != FormatYAML {
+		return writeAltFrontmatter(contact.FilePath, frontmatterFormat, &contact, contact.Content)
+	}
 
 	store := acore.NewLocalStore(filepath.Dir(contact.FilePath))
 	return acore.WriteFile(store, filepath.Base(contact.FilePath), &contact, contact.Content)
@@ -65,51 +138,96 @@ func SaveContactFile(contact model.Contact) error {
 
 // GenerateFilePath generates a file path for a new contact using acore conventions.
 func GenerateFilePath(dir string, contact model.Contact) string {
-	filename := acore.BuildFilename(contact.ID, contact.Title, "contact")
+	filename := acore.BuildFilename(contact.ID, contact.Title, contactTag)
 	return filepath.Join(dir, filename)
 }
 
-// FindContacts loads all contact files from a directory, sorted alphabetically
+// FindContacts loads all contact files from a directory, sorted alphabetically.
+// Files matching the directory's .apeopleignore are skipped.
 func FindContacts(dir string) ([]model.Contact, error) {
 	contacts := []model.Contact{}
+	err := WalkContacts(dir, func(c model.Contact) error {
+		contacts = append(contacts, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
+	// Sort alphabetically by name
+	sort.Slice(contacts, func(i, j int) bool {
+		return strings.ToLower(contacts[i].Title) < strings.ToLower(contacts[j].Title)
+	})
+
+	return contacts, nil
+}
+
+// WalkContacts parses contact files one at a time in filename order, calling fn
+// for each, so callers can stream a large store without holding it in memory.
+// Unparseable and ignored files are skipped; an error from fn stops the walk.
+func WalkContacts(dir string, fn func(model.Contact) error) error {
 	if info, err := os.Stat(dir); err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("contacts directory '%s' does not exist", dir)
+			return fmt.Errorf("contacts directory '%s' does not exist", dir)
 		}
-		return nil, fmt.Errorf("cannot access contacts directory '%s': %v", dir, err)
+		return fmt.Errorf("cannot access contacts directory '%s': %v", dir, err)
 	} else if !info.IsDir() {
-		return nil, fmt.Errorf("contacts path '%s' is not a directory", dir)
+		return fmt.Errorf("contacts path '%s' is not a directory", dir)
 	}
 
 	store := acore.NewLocalStore(dir)
 	scanner := &acore.Scanner{Store: store}
-	names, err := scanner.FindByType("contact")
+	names, err := scanner.FindByType(contactTag)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	ignore, err := loadIgnore(dir)
+	if err != nil {
+		return err
 	}
 
 	for _, name := range names {
+		if ignore.Ignored(name) {
+			continue
+		}
 		contact, err := ParseContactFile(filepath.Join(dir, name))
 		if err != nil {
 			continue // skip unparseable files
 		}
-		contacts = append(contacts, contact)
+		if err := fn(contact); err != nil {
+			return err
+		}
 	}
-
-	// Sort alphabetically by name
-	sort.Slice(contacts, func(i, j int) bool {
-		return strings.ToLower(contacts[i].Title) < strings.ToLower(contacts[j].Title)
-	})
-
-	return contacts, nil
+	return nil
 }
 
 // AssignIndexIDs ensures all contacts have index_id values, assigning new ones as needed
 func AssignIndexIDs(dir string, contacts []model.Contact) ([]model.Contact, error) {
-	counter, err := acore.NewIndexCounter(acore.NewLocalStore(dir), "apeople")
+	needed := false
+	for _, c := range contacts {
+		if c.IndexID == 0 {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return contacts, nil
+	}
+
+	unlock, err := LockCounter(dir)
+	if err != nil {
+		return contacts, err
+	}
+	defer unlock()
+
+	counter, err := IndexCounter(dir)
 	if err != nil {
-		return contacts, fmt.Errorf("failed to get ID counter: %w", err)
+		return contacts, err
+	}
+
+	used := map[int]bool{}
+	for _, c := range contacts {
+		used[c.IndexID] = true
 	}
 
 	for i, c := range contacts {
@@ -118,6 +236,16 @@ func AssignIndexIDs(dir string, contacts []model.Contact) ([]model.Contact, erro
 			if err != nil {
 				return contacts, fmt.Errorf("failed to assign index_id: %w", err)
 			}
+			if used[id] {
+				// The counter has drifted behind the files; repair it and retry
+				if _, _, err := reindexCounter(dir); err != nil {
+					return contacts, err
+				}
+				if id, err = counter.Next(); err != nil {
+					return contacts, fmt.Errorf("failed to assign index_id: %w", err)
+				}
+			}
+			used[id] = true
 			contacts[i].IndexID = id
 			if err := SaveContactFile(contacts[i]); err != nil {
 				return contacts, fmt.Errorf("failed to save index_id for %s: %w", c.Title, err)
@@ -128,7 +256,154 @@ func AssignIndexIDs(dir string, contacts []model.Contact) ([]model.Contact, erro
 	return contacts, nil
 }
 
-// FindContactByID finds a contact by index_id or ULID
+// ReindexCounter resets the index counter to one past the highest index_id found
+// in the contact files. It returns the ID the counter would have assigned before
+// the reset and the highest existing ID; previous <= max means the counter had
+// fallen behind the files and would have handed out a duplicate.
+func ReindexCounter(dir string) (previous, max int, err error) {
+	unlock, err := LockCounter(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer unlock()
+	return reindexCounter(dir)
+}
+
+// reindexCounter is ReindexCounter for callers already holding the counter lock.
+func reindexCounter(dir string) (previous, max int, err error) {
+	if previous, err = PeekIndexID(dir); err != nil {
+		return 0, 0, err
+	}
+	counter, err := IndexCounter(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	readIndexID := func(name string) (int, error) {
+		c, err := ParseContactFile(filepath.Join(dir, name))
+		if err != nil {
+			return 0, err
+		}
+		if c.IndexID > max {
+			max = c.IndexID
+		}
+		return c.IndexID, nil
+	}
+	if err := counter.InitFromFiles(contactTag, readIndexID); err != nil {
+		return 0, 0, fmt.Errorf("counter init: %w", err)
+	}
+	return previous, max, nil
+}
+
+// CheckIndexCounter reports the ID the counter would assign next (see
+// PeekIndexID) and the highest index_id in the contact files. It only reads:
+// the counter is not advanced or reset and no counter or lock file is
+// created. next <= max means the counter has fallen behind.
+func CheckIndexCounter(dir string) (next, max int, err error) {
+	if next, err = PeekIndexID(dir); err != nil {
+		return 0, 0, err
+	}
+	err = WalkContacts(dir, func(c model.Contact) error {
+		if c.IndexID > max {
+			max = c.IndexID
+		}
+		return nil
+	})
+	return next, max, err
+}
+
+// UnparseableContactFiles returns the contact files in dir that FindContacts
+// silently skips because they fail to parse, with the parse error for each.
+// Ignored files are not reported.
+func UnparseableContactFiles(dir string) (map[string]error, error) {
+	scanner := &acore.Scanner{Store: acore.NewLocalStore(dir)}
+	names, err := scanner.FindByType(contactTag)
+	if err != nil {
+		return nil, err
+	}
+	ignore, err := loadIgnore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	bad := map[string]error{}
+	for _, name := range names {
+		if ignore.Ignored(name) {
+			continue
+		}
+		if _, err := ParseContactFile(filepath.Join(dir, name)); err != nil {
+			bad[name] = err
+		}
+	}
+	return bad, nil
+}
+
+// DuplicateIndexIDs groups contacts that share a non-zero index_id. Each group
+// is ordered by created date, oldest first, so the first contact keeps the ID.
+func DuplicateIndexIDs(contacts []model.Contact) map[int][]model.Contact {
+	byID := map[int][]model.Contact{}
+	for _, c := range contacts {
+		if c.IndexID != 0 {
+			byID[c.IndexID] = append(byID[c.IndexID], c)
+		}
+	}
+
+	dupes := map[int][]model.Contact{}
+	for id, group := range byID {
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Created < group[j].Created
+		})
+		dupes[id] = group
+	}
+	return dupes
+}
+
+// AssignIndexIDsInMemory fills in missing index_id values without writing to disk.
+// It numbers contacts the way AssignIndexIDs would, from the counter's current
+// value (read, never advanced) and past the highest existing index_id if the
+// counter has fallen behind, so a number shown by a read-only command is the
+// one a later mutating command assigns and finds. Read-only commands use this
+// so they never mutate files; mutating commands should use AssignIndexIDs to
+// persist IDs via the counter.
+func AssignIndexIDsInMemory(dir string, contacts []model.Contact) []model.Contact {
+	used := map[int]bool{}
+	maxID := 0
+	for _, c := range contacts {
+		used[c.IndexID] = true
+		if c.IndexID > maxID {
+			maxID = c.IndexID
+		}
+	}
+
+	// An unreadable counter fails AssignIndexIDs too; numbering past the
+	// highest ID at least never shows an ID that is already taken
+	next, err := PeekIndexID(dir)
+	if err != nil {
+		next = maxID + 1
+	}
+	for i := range contacts {
+		if contacts[i].IndexID != 0 {
+			continue
+		}
+		if used[next] {
+			// AssignIndexIDs repairs the counter to one past the highest ID
+			next = maxID + 1
+		}
+		contacts[i].IndexID = next
+		used[next] = true
+		if next > maxID {
+			maxID = next
+		}
+		next++
+	}
+
+	return contacts
+}
+
+// FindContactByID finds a contact by index_id, ULID or (case-insensitively) alias
 func FindContactByID(contacts []model.Contact, id string) *model.Contact {
 	// Try as numeric index_id first
 	for i, c := range contacts {
@@ -144,14 +419,103 @@ func FindContactByID(contacts []model.Contact, id string) *model.Contact {
 		}
 	}
 
+	for i, c := range contacts {
+		for _, alias := range c.Aliases {
+			if strings.EqualFold(alias, id) {
+				return &contacts[i]
+			}
+		}
+	}
+
 	return nil
 }
 
+// interactionLogHeader marks the section of the body that holds the interaction log.
+var interactionLogHeader = "## Interaction Log"
+
+// SetSectionHeaders changes the headings of the interaction log and notes
+// sections, e.g. to match files written in another language. An empty
+// heading restores its default.
+func SetSectionHeaders(logHeader, notes string) {
+	if logHeader == "" {
+		logHeader = "## Interaction Log"
+	}
+	if notes == "" {
+		notes = "## Notes"
+	}
+	interactionLogHeader = logHeader
+	notesHeader = notes
+}
+
+// InteractionLogHeader returns the heading of the interaction log section.
+func InteractionLogHeader() string {
+	return interactionLogHeader
+}
+
+// NotesHeader returns the heading of the notes section.
+func NotesHeader() string {
+	return notesHeader
+}
+
+// interactionEntryRe matches log entries of the form "- **YYYY-MM-DD** (type) - note".
+// The parenthesized part may carry a duration: "(meeting, 45m)".
+var interactionEntryRe = regexp.MustCompile(`^- \*\*(\d{4}-\d{2}-\d{2})\*\* \(([^)]*)\)(?: - (.*))?$`)
+
+// durationRe matches the duration suffix inside an entry's parentheses.
+var durationRe = regexp.MustCompile(`^(\d+)m$`)
+
+// RefreshRelatedLabels rebuilds contact.RelatedContactLabels so that each entry is
+// the display name of the ULID at the same position in contact.RelatedPeople.
+// ULIDs that don't resolve to one of the given contacts keep their previously
+// cached label, falling back to the ULID itself. Returns true if labels changed.
+func RefreshRelatedLabels(contact *model.Contact, contacts []model.Contact) bool {
+	previous := map[string]string{}
+	if len(contact.RelatedContactLabels) == len(contact.RelatedPeople) {
+		for i, id := range contact.RelatedPeople {
+			previous[id] = contact.RelatedContactLabels[i]
+		}
+	}
+
+	titles := map[string]string{}
+	for _, c := range contacts {
+		titles[c.ID] = c.Title
+	}
+
+	var labels []string
+	for _, id := range contact.RelatedPeople {
+		label := titles[id]
+		if label == "" {
+			label = previous[id]
+		}
+		if label == "" {
+			label = id
+		}
+		labels = append(labels, label)
+	}
+
+	changed := len(labels) != len(contact.RelatedContactLabels)
+	for i := 0; !changed && i < len(labels); i++ {
+		changed = labels[i] != contact.RelatedContactLabels[i]
+	}
+	contact.RelatedContactLabels = labels
+	return changed
+}
+
 // AppendInteractionLog adds a log entry to the content's Interaction Log section.
-// If no "## Interaction Log" section exists, one is created.
-// New entries are inserted at the top of the log (most recent first).
+// If no "## Interaction Log" section (or the configured heading) exists, one
+// is created.
+// New entries are inserted at the top of the log (most recent first), under
+// the current year's heading when logs are grouped by year.
 func AppendInteractionLog(content string, entry string) string {
-	const header = "## Interaction Log"
+	content = appendInteractionLog(content, entry)
+	if logByYear {
+		content = ReorganizeInteractionLog(content)
+	}
+	return content
+}
+
+func appendInteractionLog(content string, entry string) string {
+	header := interactionLogHeader
 	idx := strings.Index(content, header)
 	if idx >= 0 {
 		afterHeader := idx + len(header)
@@ -174,6 +538,214 @@ func AppendInteractionLog(content string, entry string) string {
 	return trimmed + "\n\n" + header + "\n\n" + entry + "\n"
 }
 
+// InteractionLogEntry formats an Interaction Log line. The first line of
+// note follows the date and kind; any further lines are indented beneath it
+// as with IndentNote.
+func InteractionLogEntry(date time.Time, kind, note string) string {
+	entry := fmt.Sprintf("- **%s** (%s)", date.Format("2006-01-02"), kind)
+	note = strings.Trim(strings.ReplaceAll(note, "\r\n", "\n"), "\n")
+	first, rest, _ := strings.Cut(note, "\n")
+	if first = strings.TrimSpace(first); first != "" {
+		entry += " - " + first
+	}
+	return entry + IndentNote(rest)
+}
+
+// IndentNote formats a note body as a block indented under a log entry,
+// preserving line breaks. Returns "" for a blank body.
+func IndentNote(body string) string {
+	body = strings.Trim(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	if strings.TrimSpace(body) == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, line := range strings.Split(body, "\n") {
+		b.WriteString("\n")
+		if strings.TrimSpace(line) != "" {
+			b.WriteString("  " + line)
+		}
+	}
+	return b.String()
+}
+
+// InsertInteractionLog adds entry to the Interaction Log in date order (most
+// recent first), above any existing entries on the same date. Used when
+// back-filling history; for entries dated today it behaves like AppendInteractionLog.
+func InsertInteractionLog(content string, date time.Time, entry string) string {
+	content = insertInteractionLog(content, date, entry)
+	if logByYear {
+		content = ReorganizeInteractionLog(content)
+	}
+	return content
+}
+
+func insertInteractionLog(content string, date time.Time, entry string) string {
+	idx := strings.Index(content, interactionLogHeader)
+	if idx < 0 {
+		return appendInteractionLog(content, entry)
+	}
+
+	day := date.Format("2006-01-02")
+	start := idx + len(interactionLogHeader)
+	lines := strings.SplitAfter(content[start:], "\n")
+	pos := start
+	lastEntryEnd := -1
+	for _, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			break
+		}
+		if m := interactionEntryRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			if m[1] <= day {
+				return content[:pos] + entry + "\n" + content[pos:]
+			}
+		}
+		pos += len(line)
+		if strings.TrimSpace(line) != "" {
+			lastEntryEnd = pos
+		}
+	}
+
+	// Older than every entry: add after the last line of the log
+	if lastEntryEnd < 0 {
+		return appendInteractionLog(content, entry)
+	}
+	insert := entry + "\n"
+	if !strings.HasSuffix(content[:lastEntryEnd], "\n") {
+		insert = "\n" + insert
+	}
+	return content[:lastEntryEnd] + insert + content[lastEntryEnd:]
+}
+
+// ParseInteractionLog extracts the entries of the content's Interaction Log section.
+// Entries are returned in file order (most recent first). Lines that don't look like
+// log entries are skipped. Returns nil if the content has no Interaction Log section.
+func ParseInteractionLog(content string) []model.Interaction {
+	idx := strings.Index(content, interactionLogHeader)
+	if idx < 0 {
+		return nil
+	}
+
+	interactions := []model.Interaction{}
+	section := content[idx+len(interactionLogHeader):]
+	blankLines := 0
+	for _, raw := range strings.Split(section, "\n") {
+		if strings.HasPrefix(raw, "## ") {
+			break // next top-level section
+		}
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			blankLines++
+			continue
+		}
+		m := interactionEntryRe.FindStringSubmatch(line)
+		if m == nil {
+			// Indented lines continue the previous entry's note block
+			if n := len(interactions); n > 0 && strings.HasPrefix(raw, "  ") {
+				details := &interactions[n-1].Details
+				if *details != "" {
+					*details += strings.Repeat("\n", blankLines+1)
+				}
+				*details += strings.TrimPrefix(raw, "  ")
+			}
+			blankLines = 0
+			continue
+		}
+		blankLines = 0
+		date, err := time.Parse("2006-01-02", m[1])
+		if err != nil {
+			continue
+		}
+		kind, duration, _ := strings.Cut(m[2], ",")
+		// "(reclassified: network→close)": the part after the colon leads
+		// the summary
+		kind, detail, _ := strings.Cut(kind, ":")
+		summary := strings.TrimSpace(m[3])
+		if detail = strings.TrimSpace(detail); detail != "" && summary != "" {
+			summary = detail + " - " + summary
+		} else if detail != "" {
+			summary = detail
+		}
+		interaction := model.Interaction{
+			Date:    date,
+			Type:    model.InteractionType(strings.TrimSpace(kind)),
+			Summary: summary,
+		}
+		if d := durationRe.FindStringSubmatch(strings.TrimSpace(duration)); d != nil {
+			interaction.Minutes, _ = strconv.Atoi(d[1])
+		}
+		interactions = append(interactions, interaction)
+	}
+	return interactions
+}
+
+// TruncateInteractionLog keeps only the first limit entries (the most recent)
+// of the Interaction Log section, for display. Dropped entries, their note
+// blocks and any year headings after the cut are replaced by a single
+// "... and N more" line. Returns the content and the number of entries
+// dropped; limit <= 0 leaves the content unchanged.
+func TruncateInteractionLog(content string, limit int) (string, int) {
+	idx := strings.Index(content, interactionLogHeader)
+	if idx < 0 || limit <= 0 {
+		return content, 0
+	}
+
+	lines := strings.Split(content[idx:], "\n")
+	var kept []string
+	entries, dropped := 0, 0
+	cut := -1 // index in kept where the footer goes
+	end := len(lines)
+	for i, raw := range lines {
+		if i > 0 && strings.HasPrefix(raw, "## ") {
+			end = i
+			break
+		}
+		isEntry := interactionEntryRe.MatchString(strings.TrimSpace(raw))
+		if isEntry {
+			entries++
+		}
+		if cut < 0 && entries > limit {
+			cut = len(kept)
+		}
+		if cut >= 0 {
+			if isEntry {
+				dropped++
+			}
+			continue
+		}
+		kept = append(kept, raw)
+	}
+	if dropped == 0 {
+		return content, 0
+	}
+
+	// Keep a trailing year heading from dangling above the footer
+	for cut > 0 && (strings.TrimSpace(kept[cut-1]) == "" || strings.HasPrefix(kept[cut-1], "### ")) {
+		cut--
+	}
+	kept = append(kept[:cut], "", fmt.Sprintf("... and %d more", dropped), "")
+	out := content[:idx] + strings.Join(kept, "\n")
+	if end < len(lines) {
+		out += "\n" + strings.Join(lines[end:], "\n")
+	}
+	return out, dropped
+}
+
+// StripInteractionLog returns content without its Interaction Log section.
+// Any sections following the log are kept.
+func StripInteractionLog(content string) string {
+	idx := strings.Index(content, interactionLogHeader)
+	if idx < 0 {
+		return content
+	}
+
+	rest := content[idx+len(interactionLogHeader):]
+	if next := strings.Index(rest, "\n## "); next >= 0 {
+		return strings.TrimRight(content[:idx], "\n") + "\n\n" + strings.TrimLeft(rest[next:], "\n")
+	}
+	return strings.TrimRight(content[:idx], "\n") + "\n"
+}
+
 // NewContact creates a new contact with acore identity.
 func NewContact(title string, dir string) model.Contact {
 	now := time.Now()
@@ -185,6 +757,7 @@ func NewContact(title string, dir string) model.Contact {
 	contact.Type = "contact"
 	contact.Created = now.UTC().Format(time.RFC3339)
 	contact.Modified = now.UTC().Format(time.RFC3339)
+	contact.SchemaVersion = CurrentSchemaVersion
 
 	return contact
 }
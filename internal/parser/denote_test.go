@@ -0,0 +1,294 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/oklog/ulid/v2"
+)
+
+// writeTestContact saves a contact with the given title and index_id (0 for
+// none) into dir and returns it.
+func writeTestContact(t *testing.T, dir, title string, indexID int) model.Contact {
+	t.Helper()
+	c := model.Contact{}
+	c.ID = acore.NewID()
+	c.Title = title
+	c.IndexID = indexID
+	c.Type = contactTag
+	c.Tags = []string{contactTag}
+	c.Created = acore.Now()
+	c.FilePath = GenerateFilePath(dir, c)
+	if err := SaveContactFile(c); err != nil {
+		t.Fatalf("save %s: %v", title, err)
+	}
+	return c
+}
+
+// advanceCounter hands out n IDs from the counter for dir.
+func advanceCounter(t *testing.T, dir string, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, err := NextIndexID(dir); err != nil {
+			t.Fatalf("NextIndexID: %v", err)
+		}
+	}
+}
+
+func assignAndFind(t *testing.T, dir, title string) int {
+	t.Helper()
+	contacts, err := FindContacts(dir)
+	if err != nil {
+		t.Fatalf("FindContacts: %v", err)
+	}
+	if _, err := AssignIndexIDs(dir, contacts); err != nil {
+		t.Fatalf("AssignIndexIDs: %v", err)
+	}
+
+	// Re-read so the test sees what was persisted, not the in-memory slice
+	contacts, err = FindContacts(dir)
+	if err != nil {
+		t.Fatalf("FindContacts: %v", err)
+	}
+	for _, c := range contacts {
+		if c.Title == title {
+			return c.IndexID
+		}
+	}
+	t.Fatalf("contact %q not found", title)
+	return 0
+}
+
+func TestAssignIndexIDsRepairsCounterBehindFiles(t *testing.T) {
+	dir := t.TempDir()
+	advanceCounter(t, dir, 1) // counter now at 2
+	for i := 1; i <= 3; i++ {
+		writeTestContact(t, dir, fmt.Sprintf("Existing %d", i), i)
+	}
+	writeTestContact(t, dir, "Unassigned", 0)
+
+	if got := assignAndFind(t, dir, "Unassigned"); got != 4 {
+		t.Errorf("unassigned contact got index_id %d, want 4", got)
+	}
+	next, err := NextIndexID(dir)
+	if err != nil {
+		t.Fatalf("NextIndexID: %v", err)
+	}
+	if next != 5 {
+		t.Errorf("next ID after repair = %d, want 5 (max+1)", next)
+	}
+}
+
+func TestAssignIndexIDsLeavesCounterAheadAlone(t *testing.T) {
+	dir := t.TempDir()
+	advanceCounter(t, dir, 9) // counter now at 10
+	for i := 1; i <= 3; i++ {
+		writeTestContact(t, dir, fmt.Sprintf("Existing %d", i), i)
+	}
+	writeTestContact(t, dir, "Unassigned", 0)
+
+	if got := assignAndFind(t, dir, "Unassigned"); got != 10 {
+		t.Errorf("unassigned contact got index_id %d, want 10", got)
+	}
+	next, err := PeekIndexID(dir)
+	if err != nil {
+		t.Fatalf("PeekIndexID: %v", err)
+	}
+	if next != 11 {
+		t.Errorf("counter = %d, want 11", next)
+	}
+}
+
+func TestCreatedFromIdentifierSurvivesSave(t *testing.T) {
+	created := time.Date(2025, 6, 7, 8, 9, 10, 0, time.UTC)
+	id := ulid.MustNew(ulid.Timestamp(created), nil).String()
+	path := filepath.Join(t.TempDir(), id+"--grace-hopper__contact.md")
+	frontmatter := "---\nid: " + id + "\ntitle: Grace Hopper\ntype: contact\ntags:\n  - contact\n---\n"
+	if err := os.WriteFile(path, []byte(frontmatter), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := created.Format(time.RFC3339)
+	c, err := ParseContactFile(path)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if c.Created != want {
+		t.Fatalf("Created = %q, want %q from the identifier", c.Created, want)
+	}
+
+	if err := SaveContactFile(c); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	// The file itself must now carry created, not just the parsed contact
+	var saved struct {
+		Created string `yaml:"created"`
+	}
+	if _, err := acore.ReadFile(acore.NewLocalStore(filepath.Dir(path)), filepath.Base(path), &saved); err != nil {
+		t.Fatalf("read saved file: %v", err)
+	}
+	if saved.Created != want {
+		t.Errorf("saved created = %q, want %q", saved.Created, want)
+	}
+	c, err = ParseContactFile(path)
+	if err != nil {
+		t.Fatalf("re-parse: %v", err)
+	}
+	if c.Created != want {
+		t.Errorf("Created after save = %q, want %q", c.Created, want)
+	}
+}
+
+func TestCustomLogHeaderRoundTrip(t *testing.T) {
+	SetSectionHeaders("## Journal", "## Notizen")
+	t.Cleanup(func() { SetSectionHeaders("", "") })
+
+	c := writeTestContact(t, t.TempDir(), "Custom Header", 1)
+	c.Content = "## Journal\n\n- **2026-01-05** (call) - first\n"
+	if err := SaveContactFile(c); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	c, err := ParseContactFile(c.FilePath)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	when := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	c.Content = InsertInteractionLog(c.Content, when, InteractionLogEntry(when, "email", "second"))
+	if err := SaveContactFile(c); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	c, err = ParseContactFile(c.FilePath)
+	if err != nil {
+		t.Fatalf("re-parse: %v", err)
+	}
+	if strings.Contains(c.Content, "## Interaction Log") {
+		t.Errorf("default header was created:\n%s", c.Content)
+	}
+	if n := strings.Count(c.Content, "## Journal"); n != 1 {
+		t.Errorf("custom header appears %d times, want 1:\n%s", n, c.Content)
+	}
+	log := ParseInteractionLog(c.Content)
+	if len(log) != 2 || log[0].Summary != "second" || log[1].Summary != "first" {
+		t.Errorf("log under custom header = %+v, want second then first", log)
+	}
+}
+
+func TestCustomLogHeaderNewSection(t *testing.T) {
+	SetSectionHeaders("## Journal", "")
+	t.Cleanup(func() { SetSectionHeaders("", "") })
+
+	content := AppendInteractionLog("Some notes.\n", "- **2026-02-01** (text) - hi")
+	if !strings.Contains(content, "## Journal\n\n- **2026-02-01** (text) - hi") || strings.Contains(content, "## Interaction Log") {
+		t.Errorf("new log section doesn't use the custom header:\n%s", content)
+	}
+	if log := ParseInteractionLog(content); len(log) != 1 {
+		t.Errorf("found %d entries under the custom header, want 1", len(log))
+	}
+}
+
+func TestAssignIndexIDsInMemoryMatchesAssignIndexIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		advance int // IDs handed out before the contacts are written
+		want    []int
+	}{
+		{"no counter", 0, []int{4, 5}},
+		{"counter behind files", 1, []int{4, 5}},
+		{"counter at max+1", 3, []int{4, 5}},
+		{"counter ahead of files", 9, []int{10, 11}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			advanceCounter(t, dir, tt.advance)
+			for i := 1; i <= 3; i++ {
+				writeTestContact(t, dir, fmt.Sprintf("Existing %d", i), i)
+			}
+			writeTestContact(t, dir, "New A", 0)
+			writeTestContact(t, dir, "New B", 0)
+
+			contacts, err := FindContacts(dir)
+			if err != nil {
+				t.Fatalf("FindContacts: %v", err)
+			}
+			shown := map[string]int{}
+			for _, c := range AssignIndexIDsInMemory(dir, contacts) {
+				shown[c.Title] = c.IndexID
+			}
+			if got := []int{shown["New A"], shown["New B"]}; got[0] != tt.want[0] || got[1] != tt.want[1] {
+				t.Errorf("in memory: New A, New B = %v, want %v", got, tt.want)
+			}
+			if entries, _ := os.ReadDir(dir); tt.advance == 0 && len(entries) != 5 {
+				t.Errorf("in-memory numbering wrote files: %d entries, want the 5 contacts", len(entries))
+			}
+
+			for _, title := range []string{"New A", "New B"} {
+				if got := assignAndFind(t, dir, title); got != shown[title] {
+					t.Errorf("%s: list showed #%d but AssignIndexIDs gave #%d", title, shown[title], got)
+				}
+			}
+		})
+	}
+}
+
+func TestReindexCounterReportsUnconsumedPrevious(t *testing.T) {
+	dir := t.TempDir()
+	advanceCounter(t, dir, 1) // counter now at 2
+	for i := 1; i <= 3; i++ {
+		writeTestContact(t, dir, fmt.Sprintf("Existing %d", i), i)
+	}
+
+	previous, max, err := ReindexCounter(dir)
+	if err != nil {
+		t.Fatalf("ReindexCounter: %v", err)
+	}
+	if previous != 2 || max != 3 {
+		t.Errorf("ReindexCounter = %d, %d; want 2, 3", previous, max)
+	}
+	if next, err := NextIndexID(dir); err != nil || next != 4 {
+		t.Errorf("next ID after reindex = %d, %v; want 4", next, err)
+	}
+}
+
+func TestScheduledInJSON(t *testing.T) {
+	dir := t.TempDir()
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	scheduled := writeTestContact(t, dir, "Tomorrow", 1)
+	scheduled.LastContacted = &tomorrow
+	if err := SaveContactFile(scheduled); err != nil {
+		t.Fatal(err)
+	}
+	never := writeTestContact(t, dir, "Never", 2)
+
+	for _, tt := range []struct {
+		path          string
+		wantScheduled bool
+	}{
+		{scheduled.FilePath, true},
+		{never.FilePath, false},
+	} {
+		c, err := ParseContactFile(tt.path)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if c.DaysSince != -1 {
+			t.Errorf("%s: days_since_contact = %d, want -1", c.Title, c.DaysSince)
+		}
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Contains(string(data), `"scheduled":true`); got != tt.wantScheduled {
+			t.Errorf("%s: JSON has scheduled = %v, want %v: %s", c.Title, got, tt.wantScheduled, data)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Frontmatter formats understood by ParseContactFile and written by SaveContactFile.
+const (
+	FormatYAML = "yaml" // --- fenced, the acore default
+	FormatTOML = "toml" // +++ fenced
+	FormatJSON = "json" // leading JSON object
+)
+
+// frontmatterFormat is the format SaveContactFile writes.
+var frontmatterFormat = FormatYAML
+
+// SetFrontmatterFormat sets the frontmatter format used when saving contacts.
+func SetFrontmatterFormat(format string) error {
+	switch format {
+	case "", FormatYAML:
+		frontmatterFormat = FormatYAML
+	case FormatTOML, FormatJSON:
+		frontmatterFormat = format
+	default:
+		return fmt.Errorf("unsupported frontmatter format %q (use yaml, toml or json)", format)
+	}
+	return nil
+}
+
+// detectFrontmatter reports the frontmatter format of raw file contents.
+func detectFrontmatter(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("+++\n")):
+		return FormatTOML
+	case bytes.HasPrefix(data, []byte("{")):
+		return FormatJSON
+	default:
+		return FormatYAML
+	}
+}
+
+// readAltFrontmatter parses file contents with TOML or JSON frontmatter into v
+// and returns the body. Fields are mapped through YAML so the contact's yaml
+// tags apply regardless of the on-disk format.
+func readAltFrontmatter(data []byte, v interface{}) (string, error) {
+	fields := map[string]interface{}{}
+	var body string
+	switch detectFrontmatter(data) {
+	case FormatTOML:
+		rest := string(data[len("+++\n"):])
+		idx := strings.Index(rest, "\n+++")
+		if idx < 0 {
+			return "", fmt.Errorf("unterminated TOML frontmatter")
+		}
+		if _, err := toml.Decode(rest[:idx], &fields); err != nil {
+			return "", fmt.Errorf("invalid TOML frontmatter: %w", err)
+		}
+		body = strings.TrimPrefix(rest[idx+len("\n+++"):], "\n")
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if err := dec.Decode(&fields); err != nil {
+			return "", fmt.Errorf("invalid JSON frontmatter: %w", err)
+		}
+		body = strings.TrimPrefix(string(data[dec.InputOffset():]), "\n")
+	default:
+		return "", fmt.Errorf("not TOML or JSON frontmatter")
+	}
+
+	out, err := yaml.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	if err := yaml.Unmarshal(out, v); err != nil {
+		return "", err
+	}
+	return body, nil
+}
+
+// writeAltFrontmatter writes v as TOML or JSON frontmatter followed by content.
+func writeAltFrontmatter(path, format string, v interface{}, content string) error {
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	fields := map[string]interface{}{}
+	if err := yaml.Unmarshal(out, &fields); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case FormatTOML:
+		buf.WriteString("+++\n")
+		if err := toml.NewEncoder(&buf).Encode(fields); err != nil {
+			return err
+		}
+		buf.WriteString("+++\n")
+	case FormatJSON:
+		data, err := json.MarshalIndent(fields, "", "  ")
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteString("\n")
+	default:
+		return fmt.Errorf("unsupported frontmatter format %q", format)
+	}
+	buf.WriteString(content)
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
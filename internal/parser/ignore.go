@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// IgnoreFile lists gitignore-style patterns for files in the contacts
+// directory that FindContacts should skip (templates, drafts).
+const IgnoreFile = ".apeopleignore"
+
+// ignoreRule is one compiled pattern from the ignore file.
+type ignoreRule struct {
+	re     *regexp.Regexp
+	negate bool
+}
+
+// ignoreList is the parsed ignore file of one directory. The last matching
+// rule decides, so a later "!pattern" re-includes earlier matches.
+type ignoreList []ignoreRule
+
+var (
+	ignoresMu sync.Mutex
+	ignores   = map[string]ignoreList{}
+)
+
+// loadIgnore returns the ignore rules for dir, parsing the file once per run.
+// A missing file means nothing is ignored.
+func loadIgnore(dir string) (ignoreList, error) {
+	ignoresMu.Lock()
+	defer ignoresMu.Unlock()
+	if list, ok := ignores[dir]; ok {
+		return list, nil
+	}
+
+	f, err := os.Open(filepath.Join(dir, IgnoreFile))
+	if os.IsNotExist(err) {
+		ignores[dir] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", IgnoreFile, err)
+	}
+	defer f.Close()
+
+	var list ignoreList
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		rule, ok, err := parseIgnoreRule(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", IgnoreFile, line, err)
+		}
+		if ok {
+			list = append(list, rule)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", IgnoreFile, err)
+	}
+	ignores[dir] = list
+	return list, nil
+}
+
+// parseIgnoreRule compiles one line of the ignore file. Blank lines and
+// comments return ok=false.
+func parseIgnoreRule(line string) (rule ignoreRule, ok bool, err error) {
+	pattern := strings.TrimRight(line, " \t\r")
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return rule, false, nil
+	}
+	if p, neg := strings.CutPrefix(pattern, "!"); neg {
+		rule.negate = true
+		pattern = p
+	}
+	pattern = strings.TrimPrefix(pattern, `\`) // "\#" and "\!" escape a literal first character
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// A slash anywhere but the end anchors the pattern to the directory root;
+	// otherwise it matches at any depth
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var re strings.Builder
+	re.WriteString("^")
+	if !anchored {
+		re.WriteString("(?:.*/)?")
+	}
+	re.WriteString(globToRegexp(pattern))
+	if dirOnly {
+		re.WriteString("/.*$") // only things inside a matching directory
+	} else {
+		re.WriteString("(?:/.*)?$") // the path itself or anything under it
+	}
+
+	rule.re, err = regexp.Compile(re.String())
+	if err != nil {
+		return rule, false, fmt.Errorf("invalid pattern %q: %w", line, err)
+	}
+	return rule, true, nil
+}
+
+// globToRegexp translates gitignore glob syntax (*, ?, [...], **) to a regexp.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**/") {
+				b.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(glob[i:], "**") {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			if end := strings.IndexByte(glob[i+1:], ']'); end >= 0 {
+				class := glob[i+1 : i+1+end]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				b.WriteString("[" + class + "]")
+				i += end + 1
+			} else {
+				b.WriteString(`\[`)
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// Ignored reports whether the slash-separated path, relative to the contacts
+// directory, is excluded by the rules.
+func (l ignoreList) Ignored(path string) bool {
+	path = filepath.ToSlash(path)
+	ignored := false
+	for _, r := range l {
+		if r.re.MatchString(path) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
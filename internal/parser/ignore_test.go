@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func compileIgnore(t *testing.T, lines ...string) ignoreList {
+	t.Helper()
+	var list ignoreList
+	for _, line := range lines {
+		rule, ok, err := parseIgnoreRule(line)
+		if err != nil {
+			t.Fatalf("parseIgnoreRule(%q): %v", line, err)
+		}
+		if ok {
+			list = append(list, rule)
+		}
+	}
+	return list
+}
+
+func TestIgnored(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		want     bool
+	}{
+		{"no rules", nil, "a__contact.md", false},
+		{"exact name", []string{"draft.md"}, "draft.md", true},
+		{"glob", []string{"*.tmp"}, "x.tmp", true},
+		{"glob other extension", []string{"*.tmp"}, "x__contact.md", false},
+		{"question mark", []string{"draft?.md"}, "draft1.md", true},
+		{"character class", []string{"draft[0-9].md"}, "drafta.md", false},
+
+		{"unanchored matches at depth", []string{"draft.md"}, "old/2024/draft.md", true},
+		{"anchored matches at root", []string{"/draft.md"}, "draft.md", true},
+		{"anchored skips nested", []string{"/draft.md"}, "old/draft.md", false},
+		{"inner slash anchors", []string{"old/draft.md"}, "archive/old/draft.md", false},
+		{"inner slash at root", []string{"old/draft.md"}, "old/draft.md", true},
+
+		{"directory pattern covers contents", []string{"templates/"}, "templates/a__contact.md", true},
+		{"directory pattern covers nested contents", []string{"templates/"}, "templates/work/a__contact.md", true},
+		{"directory pattern at depth", []string{"templates/"}, "old/templates/a__contact.md", true},
+		{"directory pattern skips same-named file", []string{"templates/"}, "templates", false},
+		{"nested directory pattern", []string{"archive/2023/"}, "archive/2023/a__contact.md", true},
+		{"nested directory pattern other year", []string{"archive/2023/"}, "archive/2024/a__contact.md", false},
+		{"double star", []string{"**/drafts/*.md"}, "a/b/drafts/x.md", true},
+		{"double star at root", []string{"**/drafts/*.md"}, "drafts/x.md", true},
+
+		{"negation re-includes", []string{"*.md", "!keep__contact.md"}, "keep__contact.md", false},
+		{"negation leaves others ignored", []string{"*.md", "!keep__contact.md"}, "other__contact.md", true},
+		{"last rule wins", []string{"!keep.md", "*.md"}, "keep.md", true},
+		{"negation inside ignored directory", []string{"drafts/", "!drafts/ready__contact.md"}, "drafts/ready__contact.md", false},
+
+		{"comment line", []string{"# draft.md"}, "draft.md", false},
+		{"escaped hash", []string{`\#draft.md`}, "#draft.md", true},
+		{"escaped bang", []string{`\!draft.md`}, "!draft.md", true},
+		{"blank and whitespace lines", []string{"", "   ", "\t"}, "draft.md", false},
+		{"trailing whitespace trimmed", []string{"draft.md  "}, "draft.md", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compileIgnore(t, tt.patterns...).Ignored(tt.path); got != tt.want {
+				t.Errorf("Ignored(%q) with %q = %v, want %v", tt.path, tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadIgnoreFile(t *testing.T) {
+	dir := t.TempDir()
+	content := "# templates and drafts\n\ntemplates/\n*.draft.md\n!keep.draft.md\n"
+	if err := os.WriteFile(filepath.Join(dir, IgnoreFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := loadIgnore(dir)
+	if err != nil {
+		t.Fatalf("loadIgnore: %v", err)
+	}
+	if len(list) != 3 {
+		t.Errorf("got %d rules, want 3 (comment and blank line skipped)", len(list))
+	}
+	for path, want := range map[string]bool{
+		"templates/a__contact.md": true,
+		"x.draft.md":              true,
+		"keep.draft.md":           false,
+		"a__contact.md":           false,
+	} {
+		if got := list.Ignored(path); got != want {
+			t.Errorf("Ignored(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestLoadIgnoreMissingFile(t *testing.T) {
+	list, err := loadIgnore(t.TempDir())
+	if err != nil {
+		t.Fatalf("loadIgnore: %v", err)
+	}
+	if list.Ignored("a__contact.md") {
+		t.Error("a missing ignore file should ignore nothing")
+	}
+}
@@ -0,0 +1,21 @@
+//go:build windows
+
+package parser
+
+import (
+	"os"
+	"sync"
+)
+
+// Windows has no flock; fall back to serializing within this process only.
+var lockMu sync.Mutex
+
+func lockFile(f *os.File) error {
+	lockMu.Lock()
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	lockMu.Unlock()
+	return nil
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// logByYear groups new interaction log entries under "### YYYY" headings.
+var logByYear bool
+
+// SetLogByYear sets whether AppendInteractionLog and InsertInteractionLog keep
+// the log grouped under per-year headings.
+func SetLogByYear(enabled bool) {
+	logByYear = enabled
+}
+
+// yearHeadingRe matches a per-year subheading inside the interaction log.
+var yearHeadingRe = regexp.MustCompile(`^### \d{4}\s*$`)
+
+// logBlock is one log entry with its indented note block.
+type logBlock struct {
+	date  string
+	lines []string
+}
+
+// ReorganizeInteractionLog rewrites the Interaction Log section so entries sit
+// under "### YYYY" headings, newest year first and newest entry first within
+// each year. Note blocks stay with their entry. Content without a log, or
+// without any entries, is returned unchanged. Reorganizing an already grouped
+// log is a no-op.
+func ReorganizeInteractionLog(content string) string {
+	idx := strings.Index(content, interactionLogHeader)
+	if idx < 0 {
+		return content
+	}
+	start := idx + len(interactionLogHeader)
+	end := len(content)
+	if next := strings.Index(content[start:], "\n## "); next >= 0 {
+		end = start + next + 1
+	}
+
+	var preamble []string
+	var blocks []*logBlock
+	for _, line := range strings.Split(content[start:end], "\n") {
+		if yearHeadingRe.MatchString(line) {
+			continue
+		}
+		if m := interactionEntryRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			blocks = append(blocks, &logBlock{date: m[1], lines: []string{line}})
+			continue
+		}
+		if len(blocks) == 0 {
+			if strings.TrimSpace(line) != "" {
+				preamble = append(preamble, line)
+			}
+			continue
+		}
+		b := blocks[len(blocks)-1]
+		b.lines = append(b.lines, line)
+	}
+	if len(blocks) == 0 {
+		return content
+	}
+
+	// Newest first; stable so same-day entries keep their order
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].date > blocks[j].date })
+
+	var b strings.Builder
+	b.WriteString(interactionLogHeader + "\n\n")
+	for _, line := range preamble {
+		b.WriteString(line + "\n")
+	}
+	if len(preamble) > 0 {
+		b.WriteString("\n")
+	}
+	year := ""
+	for _, block := range blocks {
+		if y := block.date[:4]; y != year {
+			if year != "" {
+				b.WriteString("\n")
+			}
+			b.WriteString("### " + y + "\n\n")
+			year = y
+		}
+		lines := block.lines
+		for len(lines) > 1 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		b.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+
+	rest := content[end:]
+	if rest != "" {
+		b.WriteString("\n")
+	}
+	return content[:idx] + b.String() + rest
+}
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// appliedMappingsFile records which migration-map entries have already been
+// applied to a directory, so re-running `migrate --apply-map` is a no-op.
+const appliedMappingsFile = ".apeople-applied-mappings.json"
+
+// MappingKey returns a stable key for a migration mapping, derived from its
+// JSON encoding.
+func MappingKey(mapping interface{}) (string, error) {
+	data, err := json.Marshal(mapping)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// LoadAppliedMappings returns the keys of mappings already applied to dir.
+func LoadAppliedMappings(dir string) (map[string]bool, error) {
+	applied := map[string]bool{}
+	data, err := os.ReadFile(filepath.Join(dir, appliedMappingsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return applied, nil
+		}
+		return nil, err
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", appliedMappingsFile, err)
+	}
+	for _, k := range keys {
+		applied[k] = true
+	}
+	return applied, nil
+}
+
+// SaveAppliedMappings writes the set of applied mapping keys for dir.
+func SaveAppliedMappings(dir string, applied map[string]bool) error {
+	keys := make([]string, 0, len(applied))
+	for k := range applied {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, appliedMappingsFile), data, 0644)
+}
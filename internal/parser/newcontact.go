@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// newContactTemplate seeds the body of newly created contacts; nil leaves
+// them empty.
+var newContactTemplate *template.Template
+
+// SetNewContactTemplate sets the Go template rendered into the body of new
+// contacts. An empty text disables it.
+func SetNewContactTemplate(text string) error {
+	if text == "" {
+		newContactTemplate = nil
+		return nil
+	}
+	tmpl, err := template.New("new_contact_template").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid new_contact_template: %w", err)
+	}
+	newContactTemplate = tmpl
+	return nil
+}
+
+// ApplyNewContactTemplate renders the new-contact template with the contact's
+// fields into its Content. Contacts that already have content are left alone.
+func ApplyNewContactTemplate(contact *model.Contact) error {
+	if newContactTemplate == nil || strings.TrimSpace(contact.Content) != "" {
+		return nil
+	}
+	var b strings.Builder
+	if err := newContactTemplate.Execute(&b, contact); err != nil {
+		return fmt.Errorf("failed to render new_contact_template: %w", err)
+	}
+	contact.Content = b.String()
+	return nil
+}
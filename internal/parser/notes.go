@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// notesHeader marks the body section that holds free-form notes.
+var notesHeader = "## Notes"
+
+// FoldNotes moves a non-empty notes frontmatter value into the body's
+// "## Notes" section, so notes have a single home. The text is appended to an
+// existing Notes section, or a new one is added before the Interaction Log
+// (or at the end). Returns true if the contact changed.
+func FoldNotes(c *model.Contact) bool {
+	notes := strings.TrimSpace(c.Notes)
+	c.Notes = ""
+	if notes == "" {
+		return false
+	}
+
+	content := c.Content
+	if idx := sectionIndex(content, notesHeader); idx >= 0 {
+		// Append at the end of the existing section
+		end := len(content)
+		if next := strings.Index(content[idx+len(notesHeader):], "\n## "); next >= 0 {
+			end = idx + len(notesHeader) + next
+		}
+		section := strings.TrimRight(content[idx:end], "\n")
+		c.Content = content[:idx] + section + "\n\n" + notes + "\n" + paddedRest(content[end:])
+		return true
+	}
+
+	block := notesHeader + "\n\n" + notes + "\n"
+	if idx := sectionIndex(content, interactionLogHeader); idx >= 0 {
+		c.Content = content[:idx] + block + "\n" + content[idx:]
+		return true
+	}
+	if strings.TrimSpace(content) == "" {
+		c.Content = block
+	} else {
+		c.Content = strings.TrimRight(content, "\n") + "\n\n" + block
+	}
+	return true
+}
+
+// ContactNotes returns the contact's notes wherever they live: the body's
+// "## Notes" section followed by any notes frontmatter not yet folded in.
+func ContactNotes(c model.Contact) string {
+	var parts []string
+	if idx := sectionIndex(c.Content, notesHeader); idx >= 0 {
+		section := c.Content[idx+len(notesHeader):]
+		if next := strings.Index(section, "\n## "); next >= 0 {
+			section = section[:next]
+		}
+		if s := strings.TrimSpace(section); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if s := strings.TrimSpace(c.Notes); s != "" {
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// HasContent reports whether the contact has anything written beyond its
+// frontmatter: interaction log entries, notes (in the body or frontmatter)
+// or other body text. Headings, the links block and whitespace don't count.
+func HasContent(c model.Contact) bool {
+	if strings.TrimSpace(c.Notes) != "" || len(ParseInteractionLog(c.Content)) > 0 {
+		return true
+	}
+	body := acore.StripLinksBlock(StripInteractionLog(c.Content))
+	for _, line := range strings.Split(body, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionIndex returns the offset of a "## " heading line in content, or -1.
+func sectionIndex(content, header string) int {
+	if strings.HasPrefix(content, header+"\n") || content == header {
+		return 0
+	}
+	if idx := strings.Index(content, "\n"+header+"\n"); idx >= 0 {
+		return idx + 1
+	}
+	if strings.HasSuffix(content, "\n"+header) {
+		return len(content) - len(header)
+	}
+	return -1
+}
+
+// paddedRest returns the content after a section with a blank line before
+// the next heading, or "" at the end of the body.
+func paddedRest(rest string) string {
+	rest = strings.TrimLeft(rest, "\n")
+	if rest == "" {
+		return ""
+	}
+	return "\n" + rest
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// TrashDirName is the subdirectory of the contacts directory that holds soft-deleted contacts.
+const TrashDirName = ".trash"
+
+// TrashDir returns the trash directory for a contacts directory.
+func TrashDir(dir string) string {
+	return filepath.Join(dir, TrashDirName)
+}
+
+// TrashContact soft-deletes a contact by moving its file into the trash directory,
+// stamping deleted_at in the frontmatter. A file of the same name already in
+// the trash is kept; the new one gets a numbered name (see trashName).
+// Returns the new file path.
+func TrashContact(contact model.Contact) (string, error) {
+	trashDir := TrashDir(filepath.Dir(contact.FilePath))
+	if err := os.MkdirAll(trashDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	original := contact.FilePath
+	contact.DeletedAt = acore.Now()
+	contact.FilePath = trashName(trashDir, filepath.Base(original))
+	if err := SaveContactFile(contact); err != nil {
+		return "", err
+	}
+	if err := os.Remove(original); err != nil {
+		return "", err
+	}
+	return contact.FilePath, nil
+}
+
+// RestoreContact moves a trashed contact back into the contacts directory
+// under its original name and clears deleted_at. Refuses to overwrite an
+// existing file. Returns the restored path.
+func RestoreContact(dir string, contact model.Contact) (string, error) {
+	trashed := contact.FilePath
+	target := filepath.Join(dir, trashSuffixRe.ReplaceAllString(filepath.Base(trashed), "$1"))
+	if _, err := os.Stat(target); err == nil {
+		return "", fmt.Errorf("cannot restore: %s already exists", target)
+	}
+
+	contact.DeletedAt = ""
+	contact.FilePath = target
+	if err := SaveContactFile(contact); err != nil {
+		return "", err
+	}
+	if err := os.Remove(trashed); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// trashSuffixRe matches the "~N" trashName adds before the Denote keywords.
+var trashSuffixRe = regexp.MustCompile(`~\d+(__[^/]*\.md)$`)
+
+// trashName returns the path for name in trashDir, numbering it
+// ("ID--slug~2__contact.md") if that name is taken. The number goes before
+// the keywords so the file is still found as a contact.
+func trashName(trashDir, name string) string {
+	path := filepath.Join(trashDir, name)
+	if _, err := os.Stat(path); err != nil {
+		return path
+	}
+	stem, keywords := name, ""
+	if i := strings.LastIndex(name, "__"); i >= 0 {
+		stem, keywords = name[:i], name[i:]
+	}
+	for n := 2; ; n++ {
+		path = filepath.Join(trashDir, stem+"~"+strconv.Itoa(n)+keywords)
+		if _, err := os.Stat(path); err != nil {
+			return path
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrashContactKeepsEarlierFile(t *testing.T) {
+	dir := t.TempDir()
+	first := writeTestContact(t, dir, "Ada Lovelace", 1)
+	firstTrashed, err := TrashContact(first)
+	if err != nil {
+		t.Fatalf("trash first: %v", err)
+	}
+	before, err := os.ReadFile(firstTrashed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A contact with the same file name, e.g. re-imported and deleted again
+	second := first
+	second.Company = "Analytical Engines"
+	if err := SaveContactFile(second); err != nil {
+		t.Fatal(err)
+	}
+	secondTrashed, err := TrashContact(second)
+	if err != nil {
+		t.Fatalf("trash second: %v", err)
+	}
+
+	if secondTrashed == firstTrashed {
+		t.Fatalf("second contact trashed over the first at %s", firstTrashed)
+	}
+	if after, err := os.ReadFile(firstTrashed); err != nil || string(after) != string(before) {
+		t.Errorf("first trashed file changed or went missing: %v", err)
+	}
+	trashed, err := FindContacts(TrashDir(dir))
+	if err != nil || len(trashed) != 2 {
+		t.Fatalf("trash holds %d contacts, %v; want 2", len(trashed), err)
+	}
+
+	restored, err := RestoreContact(dir, trashed[0])
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored != first.FilePath {
+		t.Errorf("restored to %s, want the original name %s", restored, first.FilePath)
+	}
+	// Both copies restore to the same name, so the second must not overwrite
+	if _, err := RestoreContact(dir, trashed[1]); err == nil {
+		t.Error("restoring the other copy overwrote the restored contact")
+	}
+}
+
+func TestTrashName(t *testing.T) {
+	dir := t.TempDir()
+	name := "01ABC--ada-lovelace__contact.md"
+	if got := trashName(dir, name); got != filepath.Join(dir, name) {
+		t.Errorf("free name = %s", got)
+	}
+	for _, taken := range []string{name, "01ABC--ada-lovelace~2__contact.md"} {
+		if err := os.WriteFile(filepath.Join(dir, taken), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := filepath.Join(dir, "01ABC--ada-lovelace~3__contact.md")
+	if got := trashName(dir, name); got != want {
+		t.Errorf("taken name = %s, want %s", got, want)
+	}
+	if got := trashSuffixRe.ReplaceAllString(filepath.Base(want), "$1"); got != name {
+		t.Errorf("restored name = %s, want %s", got, name)
+	}
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"time"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// CurrentSchemaVersion is the frontmatter layout written for new contacts and
+// by `apeople upgrade`. Files without schema_version are version 1.
+const CurrentSchemaVersion = 3
+
+// schemaUpgrade moves a contact from one schema version to the next.
+type schemaUpgrade struct {
+	description string
+	apply       func(c *model.Contact)
+}
+
+// schemaUpgrades[i] upgrades version i+1 to i+2. Each step must be safe to run
+// on a contact that already has the newer layout.
+var schemaUpgrades = []schemaUpgrade{
+	{"move updated_at to modified and normalize timestamps", upgradeTimestamps},
+	{"fold the notes field into the ## Notes section", func(c *model.Contact) { FoldNotes(c) }},
+}
+
+// SchemaVersionOf returns the contact's schema version, treating unset as 1.
+func SchemaVersionOf(c model.Contact) int {
+	if c.SchemaVersion < 1 {
+		return 1
+	}
+	return c.SchemaVersion
+}
+
+// UpgradeContact applies every upgrade step between the contact's schema
+// version and CurrentSchemaVersion and stamps the new version. It returns the
+// descriptions of the steps applied; none means the contact was current.
+// Contacts from a newer apeople are left alone.
+func UpgradeContact(c *model.Contact) []string {
+	var applied []string
+	for v := SchemaVersionOf(*c); v < CurrentSchemaVersion; v++ {
+		step := schemaUpgrades[v-1]
+		step.apply(c)
+		applied = append(applied, step.description)
+	}
+	if len(applied) > 0 {
+		c.SchemaVersion = CurrentSchemaVersion
+	}
+	return applied
+}
+
+// upgradeTimestamps (1 -> 2) drops the legacy updated_at key (ParseContactFile
+// already reads it into modified) and rewrites created as RFC 3339 in UTC, the
+// form acore writes. modified is refreshed when the contact is saved.
+func upgradeTimestamps(c *model.Contact) {
+	c.LegacyUpdatedAt = ""
+	c.Created = normalizeTimestamp(c.Created)
+}
+
+// timestampLayouts are the forms older files used for created/modified.
+var timestampLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "20060102T150405", "2006-01-02"}
+
+// normalizeTimestamp rewrites a timestamp in any of timestampLayouts as
+// RFC 3339 UTC. Unrecognized values are returned unchanged.
+func normalizeTimestamp(s string) string {
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const v1Fixture = "20240305T143000--ada-lovelace__contact.md"
+
+// upgradeFile upgrades the contact at path the way `apeople upgrade` does,
+// but without refreshing modified, and returns the steps applied.
+func upgradeFile(t *testing.T, path string) []string {
+	t.Helper()
+	c, err := ParseContactFile(path)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	steps := UpgradeContact(&c)
+	if err := writeContactFile(c); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return steps
+}
+
+// copyFixture copies a testdata file into a temp dir and returns its path.
+func copyFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// useUTC makes time.Local UTC for the test, so zoneless fixture timestamps
+// normalize the same everywhere.
+func useUTC(t *testing.T) {
+	t.Helper()
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+}
+
+func TestUpgradeSchema1(t *testing.T) {
+	useUTC(t)
+	path := copyFixture(t, v1Fixture)
+
+	if steps := upgradeFile(t, path); len(steps) != len(schemaUpgrades) {
+		t.Errorf("applied %d steps, want %d: %q", len(steps), len(schemaUpgrades), steps)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "upgraded-v1.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("upgraded file differs from testdata/upgraded-v1.golden\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUpgradeIsIdempotent(t *testing.T) {
+	useUTC(t)
+	path := copyFixture(t, v1Fixture)
+	upgradeFile(t, path)
+	once, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if steps := upgradeFile(t, path); len(steps) != 0 {
+		t.Errorf("second upgrade applied %q, want nothing", steps)
+	}
+	twice, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(once, twice) {
+		t.Errorf("second upgrade changed the file\nfirst:\n%s\nsecond:\n%s", once, twice)
+	}
+}
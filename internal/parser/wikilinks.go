@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// wikiLinkRe matches an inline [[ULID]] or [[ULID|label]] reference.
+var wikiLinkRe = regexp.MustCompile(`\[\[([0-9A-Za-z]{26})(?:\|[^\]\n]*)?\]\]`)
+
+// WikiLinks returns the ULIDs referenced as [[ULID]] or [[ULID|label]] in
+// content, upper-cased, in order of first appearance.
+func WikiLinks(content string) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, m := range wikiLinkRe.FindAllStringSubmatch(content, -1) {
+		id := strings.ToUpper(m[1])
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// LinksTo reports whether the contact references id, either in one of its
+// related_people/related_tasks/related_ideas lists or as a [[id]] link in
+// its body.
+func LinksTo(c *model.Contact, id string) bool {
+	for _, list := range [][]string{c.RelatedPeople, c.RelatedTasks, c.RelatedIdeas} {
+		for _, x := range list {
+			if strings.EqualFold(x, id) {
+				return true
+			}
+		}
+	}
+	for _, link := range WikiLinks(c.Content) {
+		if strings.EqualFold(link, id) {
+			return true
+		}
+	}
+	return false
+}
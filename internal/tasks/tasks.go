@@ -0,0 +1,175 @@
+// Package tasks writes atask follow-up tasks for contacts whose state calls for action.
+package tasks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// titlePrefixes maps action states to the verb used in the task title.
+// States without an entry use "Follow up with".
+var titlePrefixes = map[string]string{
+	"followup":  "Follow up with",
+	"ping":      "Ping",
+	"scheduled": "Meeting with",
+	"timeout":   "Follow up with",
+}
+
+// taskFile is an atask task as written to disk.
+type taskFile struct {
+	acore.Entity `yaml:",inline"`
+	Status       string `yaml:"status"`
+	Label        string `yaml:"label,omitempty"`
+}
+
+// templateData is what task_title_template and task_tag_template are
+// rendered with.
+type templateData struct {
+	Name   string // contact title
+	State  string // the state that called for the task
+	Action string // verb for the state, e.g. "Ping" or "Follow up with"
+	Label  string // contact label
+}
+
+// Dir returns the directory tasks are written to and read from:
+// task_directory when set, otherwise atask's directory from the acore config.
+func Dir(cfg *config.Config) string {
+	if cfg.TaskDirectory != "" {
+		return cfg.TaskDirectory
+	}
+	acoreCfg, _ := acore.LoadConfig()
+	return acoreCfg.DirFor("atask")
+}
+
+// CreateForContact creates a task when a contact changes to an action-requiring
+// state. It does nothing (and reports false) when task creation is disabled or
+// the state is not one of the configured task states.
+func CreateForContact(cfg *config.Config, contact model.Contact, newState string) (bool, error) {
+	if !cfg.CreatesTaskFor(newState) {
+		return false, nil
+	}
+
+	action, ok := titlePrefixes[newState]
+	if !ok {
+		action = "Follow up with"
+	}
+	data := templateData{Name: contact.Title, State: newState, Action: action, Label: contact.Label}
+	taskTitle, err := render(cfg.TaskTitleTemplate, data)
+	if err != nil {
+		return false, fmt.Errorf("task_title_template: %w", err)
+	}
+	taskTag, err := render(*cfg.TaskTagTemplate, data)
+	if err != nil {
+		return false, fmt.Errorf("task_tag_template: %w", err)
+	}
+
+	now := acore.Now()
+	task := taskFile{
+		Entity: acore.Entity{
+			ID:            acore.NewID(),
+			Title:         taskTitle,
+			Type:          "task",
+			Tags:          []string{},
+			Created:       now,
+			Modified:      now,
+			RelatedPeople: []string{contact.ID},
+		},
+		Status: "open",
+		Label:  contact.Label,
+	}
+	if taskTag != "" {
+		task.Tags = append(task.Tags, taskTag)
+	}
+
+	// Add task description
+	var body string
+	switch newState {
+	case "followup":
+		body = fmt.Sprintf("Follow up with %s regarding previous conversation.\n", contact.Title)
+	case "ping":
+		body = fmt.Sprintf("Send a quick check-in message to %s.\n", contact.Title)
+	case "scheduled":
+		body = fmt.Sprintf("Scheduled meeting or call with %s.\n", contact.Title)
+	case "timeout":
+		body = fmt.Sprintf("%s has not responded. Consider following up or closing the loop.\n", contact.Title)
+	default:
+		body = fmt.Sprintf("Follow up with %s (state: %s).\n", contact.Title, newState)
+	}
+
+	dir := Dir(cfg)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, fmt.Errorf("failed to create task directory: %v", err)
+	}
+
+	// The YAML encoder quotes titles with colons, quotes and the like
+	filename := acore.BuildFilename(task.ID, taskTitle, "task")
+	if err := acore.WriteFile(acore.NewLocalStore(dir), filename, &task, "\n"+body); err != nil {
+		return false, fmt.Errorf("failed to create task file '%s': %v", filename, err)
+	}
+
+	return true, nil
+}
+
+// render executes a task template, trimming surrounding whitespace.
+func render(text string, data templateData) (string, error) {
+	tmpl, err := template.New("task").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// closedStatuses are atask statuses that no longer call for action.
+var closedStatuses = []string{"done", "dropped", "cancelled", "canceled", "archived"}
+
+// Task is the part of an atask task that matters for contacts.
+type Task struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Status   string   `json:"status"`
+	People   []string `json:"-"`
+	FilePath string   `json:"file_path"`
+}
+
+// Open reads the open tasks in the task directory. A missing directory means
+// no tasks; unreadable files are skipped.
+func Open(cfg *config.Config) ([]Task, error) {
+	dir := Dir(cfg)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	store := acore.NewLocalStore(dir)
+	names, err := (&acore.Scanner{Store: store}).FindByType("task")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan tasks: %w", err)
+	}
+
+	var open []Task
+	for _, name := range names {
+		var fm taskFile
+		if _, err := acore.ReadFile(store, name, &fm); err != nil {
+			continue
+		}
+		if slices.Contains(closedStatuses, strings.ToLower(fm.Status)) {
+			continue
+		}
+		open = append(open, Task{
+			ID: fm.ID, Title: fm.Title, Status: fm.Status,
+			People: fm.RelatedPeople, FilePath: filepath.Join(dir, name),
+		})
+	}
+	return open, nil
+}
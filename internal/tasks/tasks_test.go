@@ -0,0 +1,131 @@
+package tasks
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/apeople/internal/config"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+)
+
+// testConfig writes tasks to a temp dir with the given title template and
+// the default tag template.
+func testConfig(t *testing.T, titleTemplate string) *config.Config {
+	t.Helper()
+	tag := config.DefaultTaskTagTemplate
+	return &config.Config{
+		TaskDirectory:     t.TempDir(),
+		TaskStates:        config.DefaultTaskStates,
+		TaskTitleTemplate: titleTemplate,
+		TaskTagTemplate:   &tag,
+	}
+}
+
+// readOnlyTask reads back the single task file in the task directory.
+func readOnlyTask(t *testing.T, cfg *config.Config) (taskFile, string) {
+	t.Helper()
+	entries, err := os.ReadDir(cfg.TaskDirectory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("task directory has %d files, want 1", len(entries))
+	}
+	var task taskFile
+	body, err := acore.ReadFile(acore.NewLocalStore(cfg.TaskDirectory), entries[0].Name(), &task)
+	if err != nil {
+		t.Fatalf("task file doesn't parse: %v", err)
+	}
+	return task, body
+}
+
+func TestCreateForContactTrickyTitle(t *testing.T) {
+	name := `#1 "Doc" O'Brien: CEO --- Zoë 日本`
+	contact := model.Contact{Label: "board: chair"}
+	contact.ID = acore.NewID()
+	contact.Title = name
+
+	cfg := testConfig(t, "{{.Name}}")
+	created, err := CreateForContact(cfg, contact, "ping")
+	if err != nil || !created {
+		t.Fatalf("CreateForContact = %v, %v; want a task", created, err)
+	}
+
+	task, body := readOnlyTask(t, cfg)
+	if task.Title != name {
+		t.Errorf("title = %q, want %q", task.Title, name)
+	}
+	if want := []string{"contact-ping"}; !slices.Equal(task.Tags, want) {
+		t.Errorf("tags = %q, want %q", task.Tags, want)
+	}
+	if task.Label != contact.Label {
+		t.Errorf("label = %q, want %q", task.Label, contact.Label)
+	}
+	if !slices.Equal(task.RelatedPeople, []string{contact.ID}) || task.Status != "open" || task.Type != "task" {
+		t.Errorf("task = %+v, want an open task related to %s", task, contact.ID)
+	}
+	if want := "Send a quick check-in message to " + name + "."; strings.TrimSpace(body) != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+func TestCreateForContactSkipsOtherStates(t *testing.T) {
+	cfg := testConfig(t, config.DefaultTaskTitleTemplate)
+	if created, err := CreateForContact(cfg, model.Contact{}, "ok"); created || err != nil {
+		t.Errorf("CreateForContact for ok = %v, %v; want no task", created, err)
+	}
+	if entries, _ := os.ReadDir(cfg.TaskDirectory); len(entries) != 0 {
+		t.Errorf("wrote %d files for a state without tasks", len(entries))
+	}
+}
+
+// Task files used to be built with "title: %s", so a name with YAML syntax
+// in it produced a file atask couldn't read.
+func TestCreateForContactYAMLSpecialTitles(t *testing.T) {
+	names := []string{
+		"Smith: CEO",
+		"# not a comment",
+		"Ann # Smith",
+		"- Bob",
+		"[Carol]",
+		"{Dave}",
+		"'Eve",
+		`"Frank`,
+		"*Grace",
+		"&Heidi",
+		"!Ivan",
+		"%Judy",
+		"@Mallory",
+		"`Niaj",
+		"| Olivia",
+		"> Peggy",
+		"---",
+		"yes",
+		"null",
+		"42",
+		"Trent\\",
+	}
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			contact := model.Contact{}
+			contact.ID = acore.NewID()
+			contact.Title = name
+
+			cfg := testConfig(t, "{{.Name}}")
+			if _, err := CreateForContact(cfg, contact, "followup"); err != nil {
+				t.Fatalf("CreateForContact: %v", err)
+			}
+			if task, _ := readOnlyTask(t, cfg); task.Title != name {
+				t.Errorf("title = %q, want %q", task.Title, name)
+			}
+
+			open, err := Open(cfg)
+			if err != nil || len(open) != 1 || open[0].Title != name {
+				t.Errorf("Open = %+v, %v; want the task titled %q", open, err, name)
+			}
+		})
+	}
+}
@@ -3,14 +3,13 @@ package ui
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/mph-llm-experiments/acore"
 	"github.com/mph-llm-experiments/apeople/internal/model"
 	"github.com/mph-llm-experiments/apeople/internal/parser"
+	"github.com/mph-llm-experiments/apeople/internal/tasks"
 )
 
 // Message types
@@ -61,10 +60,7 @@ func (m Model) logContactInteraction(contact model.Contact) tea.Cmd {
 		contact.State = m.interactionState
 		
 		// Add interaction log entry
-		logEntry := fmt.Sprintf("- **%s** (%s)", now.Format("2006-01-02"), m.interactionType)
-		if m.interactionNote != "" {
-			logEntry += fmt.Sprintf(" - %s", m.interactionNote)
-		}
+		logEntry := parser.InteractionLogEntry(now, m.interactionType, m.interactionNote)
 		contact.Content = parser.AppendInteractionLog(contact.Content, logEntry)
 		
 		// Save the updated contact
@@ -77,15 +73,12 @@ func (m Model) logContactInteraction(contact model.Contact) tea.Cmd {
 		var taskCreated bool
 		var taskError string
 		if oldState != m.interactionState {
-			if err := m.createTaskForContact(contact, m.interactionState); err != nil {
+			created, err := tasks.CreateForContact(m.cfg, contact, m.interactionState)
+			if err != nil {
 				// Include error in message so user knows what happened
 				taskError = fmt.Sprintf(" [task error: %v]", err)
-			} else if _, needsTask := map[string]bool{
-				"followup": true, "ping": true, "scheduled": true, 
-				"timeout": true,
-			}[m.interactionState]; needsTask {
-				taskCreated = true
 			}
+			taskCreated = created
 		}
 		
 		// Reload the contact to get the updated state
@@ -168,11 +161,11 @@ func (m Model) saveEditedContact() tea.Cmd {
 		
 		// Parse and update tags
 		tagStr := strings.TrimSpace(m.editValues[fieldTags])
-		tags := []string{"contact"} // Always include the contact tag
+		tags := []string{parser.ContactTag()} // Always include the contact tag
 		if tagStr != "" {
 			for _, tag := range strings.Fields(tagStr) {
 				tag = strings.TrimPrefix(tag, "#")
-				if tag != "" && tag != "contact" {
+				if tag != "" && tag != parser.ContactTag() {
 					tags = append(tags, tag)
 				}
 			}
@@ -192,15 +185,8 @@ func (m Model) saveEditedContact() tea.Cmd {
 		// Create task if state changed to one requiring action
 		var taskCreated bool
 		if oldState != contact.State {
-			if err := m.createTaskForContact(contact, contact.State); err != nil {
-				// Log error but don't fail the edit
-				// The contact update was successful even if task creation failed
-			} else if _, needsTask := map[string]bool{
-				"followup": true, "ping": true, "scheduled": true,
-				"timeout": true,
-			}[contact.State]; needsTask {
-				taskCreated = true
-			}
+			// A task failure doesn't fail the edit; the contact update was successful
+			taskCreated, _ = tasks.CreateForContact(m.cfg, contact, contact.State)
 		}
 		
 		// Reload the contact to get the updated state
@@ -221,82 +207,6 @@ func (m Model) saveEditedContact() tea.Cmd {
 	}
 }
 
-// createTaskForContact creates a task when a contact changes to an action-requiring state
-func (m Model) createTaskForContact(contact model.Contact, newState string) error {
-	// Only create tasks for states that require action
-	actionStates := map[string]string{
-		"followup":  "Follow up with",
-		"ping":      "Ping",
-		"scheduled": "Meeting with",
-		"timeout":   "Follow up with",
-	}
-	
-	taskPrefix, needsTask := actionStates[newState]
-	if !needsTask {
-		return nil // No task needed for this state
-	}
-	
-	// Generate task title
-	taskTitle := fmt.Sprintf("%s %s", taskPrefix, contact.Title)
-	if newState == "timeout" {
-		taskTitle += " (no response)"
-	}
-	
-	// Generate task using acore identity
-	now := time.Now()
-	taskID := acore.NewID()
-
-	// Create tags based on contact
-	tags := []string{fmt.Sprintf("contact-%s", newState)}
-
-	// Create task content
-	var taskContent strings.Builder
-	taskContent.WriteString("---\n")
-	taskContent.WriteString(fmt.Sprintf("id: %s\n", taskID))
-	taskContent.WriteString(fmt.Sprintf("title: %s\n", taskTitle))
-	taskContent.WriteString(fmt.Sprintf("type: task\n"))
-	taskContent.WriteString(fmt.Sprintf("tags: [%s]\n", strings.Join(tags, ", ")))
-	taskContent.WriteString(fmt.Sprintf("created: %s\n", now.UTC().Format(time.RFC3339)))
-	taskContent.WriteString(fmt.Sprintf("modified: %s\n", now.UTC().Format(time.RFC3339)))
-	taskContent.WriteString("status: open\n")
-	if contact.Label != "" {
-		taskContent.WriteString(fmt.Sprintf("label: %s\n", contact.Label))
-	}
-	taskContent.WriteString(fmt.Sprintf("related_people:\n  - %s\n", contact.ID))
-	taskContent.WriteString("---\n\n")
-	
-	// Add task description
-	switch newState {
-	case "followup":
-		taskContent.WriteString(fmt.Sprintf("Follow up with %s regarding previous conversation.\n", contact.Title))
-	case "ping":
-		taskContent.WriteString(fmt.Sprintf("Send a quick check-in message to %s.\n", contact.Title))
-	case "scheduled":
-		taskContent.WriteString(fmt.Sprintf("Scheduled meeting or call with %s.\n", contact.Title))
-	case "timeout":
-		taskContent.WriteString(fmt.Sprintf("%s has not responded. Consider following up or closing the loop.\n", contact.Title))
-	}
-	
-	// Save task file using acore filename convention
-	filename := acore.BuildFilename(taskID, taskTitle, "task")
-	// Save tasks to the atask directory via acore config
-	acoreCfg, _ := acore.LoadConfig()
-	notesDir := acoreCfg.DirFor("atask")
-	
-	// Create notes directory if it doesn't exist
-	if err := os.MkdirAll(notesDir, 0755); err != nil {
-		return fmt.Errorf("failed to create notes directory: %v", err)
-	}
-	
-	taskPath := filepath.Join(notesDir, filename)
-	
-	if err := os.WriteFile(taskPath, []byte(taskContent.String()), 0644); err != nil {
-		return fmt.Errorf("failed to create task file '%s': %v", filename, err)
-	}
-	
-	return nil
-}
-
 // saveQuickTypeChange returns a command that saves a quick type change
 func (m Model) saveQuickTypeChange(contact model.Contact) tea.Cmd {
 	return func() tea.Msg {
@@ -345,11 +255,11 @@ func (m Model) saveNewContact() tea.Cmd {
 		
 		// Parse and set tags
 		tagStr := strings.TrimSpace(m.editValues[fieldTags])
-		tags := []string{"contact"} // Always include the contact tag
+		tags := []string{parser.ContactTag()} // Always include the contact tag
 		if tagStr != "" {
 			for _, tag := range strings.Fields(tagStr) {
 				tag = strings.TrimPrefix(tag, "#")
-				if tag != "" && tag != "contact" {
+				if tag != "" && tag != parser.ContactTag() {
 					tags = append(tags, tag)
 				}
 			}
@@ -363,6 +273,10 @@ func (m Model) saveNewContact() tea.Cmd {
 			return errorMsg{err: fmt.Errorf("cannot access contacts directory '%s': %v", m.contactsDir, err)}
 		}
 		
+		if err := parser.ApplyNewContactTemplate(&contact); err != nil {
+			return errorMsg{err: err}
+		}
+
 		// Generate filename using acore conventions
 		contact.FilePath = parser.GenerateFilePath(m.contactsDir, contact)
 		
@@ -375,15 +289,8 @@ func (m Model) saveNewContact() tea.Cmd {
 		// Create task if new contact has an action-requiring state
 		var taskCreated bool
 		if contact.State != "" && contact.State != "ok" {
-			if err := m.createTaskForContact(contact, contact.State); err != nil {
-				// Log error but don't fail the contact creation
-				// The contact was created successfully even if task creation failed
-			} else if _, needsTask := map[string]bool{
-				"followup": true, "ping": true, "scheduled": true,
-				"timeout": true,
-			}[contact.State]; needsTask {
-				taskCreated = true
-			}
+			// A task failure doesn't fail the creation; the contact was created successfully
+			taskCreated, _ = tasks.CreateForContact(m.cfg, contact, contact.State)
 		}
 		
 		// Reload the contact to get the saved state
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mph-llm-experiments/acore"
 	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
 )
 
 // Detail view styles
@@ -30,6 +31,10 @@ var (
 
 // updateDetail handles input in detail view
 func (m Model) updateDetail(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.scrollHistory(msg.String()) {
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "esc", "q":
 		m.currentView = ViewList
@@ -90,7 +95,31 @@ func (m Model) viewDetail() string {
 		b.WriteString(messageStyle.Render("→ " + m.message))
 		b.WriteString("\n\n")
 	}
+
+	// Contact fields, with the interaction history beside them on wide
+	// terminals and below them otherwise
+	fields := m.renderDetailFields(contact)
+	history := sectionStyle.Render("Interaction History") + "\n" + m.history.View()
+	if m.width >= sideBySideWidth {
+		left := lipgloss.NewStyle().Width(m.width - m.history.Width - 2).Render(fields)
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, history))
+	} else {
+		b.WriteString(fields)
+		b.WriteString("\n")
+		b.WriteString(history)
+	}
+	b.WriteString("\n")
 	
+	// Footer
+	b.WriteString(m.renderDetailFooter())
+	
+	return b.String()
+}
+
+// renderDetailFields renders the contact's field sections and notes
+func (m Model) renderDetailFields(contact model.Contact) string {
+	var b strings.Builder
+
 	// Basic Information
 	b.WriteString(sectionStyle.Render("Contact Information"))
 	b.WriteString("\n")
@@ -117,18 +146,21 @@ func (m Model) viewDetail() string {
 	b.WriteString(m.renderContactHistory(contact))
 	b.WriteString("\n")
 	
-	// Notes/Content
-	if contact.Content != "" {
-		b.WriteString(sectionStyle.Render("Recent Interactions"))
+	// Body text other than the interaction log (which has its own pane),
+	// plus any notes not yet folded into the body
+	body := strings.TrimSpace(acore.StripLinksBlock(parser.StripInteractionLog(contact.Content)))
+	body = strings.TrimSpace(strings.TrimPrefix(body, parser.NotesHeader()))
+	if notes := strings.TrimSpace(contact.Notes); notes != "" {
+		body = strings.TrimSpace(body + "\n\n" + notes)
+	}
+	if body != "" {
+		b.WriteString(sectionStyle.Render("Notes"))
 		b.WriteString("\n")
-		b.WriteString(m.renderContactContent(contact))
+		b.WriteString(m.renderContactContent(body))
 		b.WriteString("\n")
 	}
-	
-	// Footer
-	b.WriteString(m.renderDetailFooter())
-	
-	return b.String()
+
+	return strings.TrimRight(b.String(), "\n")
 }
 
 // renderDetailHeader renders the contact name and status
@@ -150,7 +182,9 @@ func (m Model) renderDetailHeader(contact model.Contact) string {
 	
 	// Days since contact
 	days := contact.DaysSinceContact()
-	if days >= 0 {
+	if contact.IsScheduled() {
+		status = append(status, fmt.Sprintf("Scheduled in %d days", -days))
+	} else if days >= 0 {
 		status = append(status, fmt.Sprintf("%d days since contact", days))
 	} else {
 		status = append(status, "Never contacted")
@@ -165,14 +199,22 @@ func (m Model) renderDetailHeader(contact model.Contact) string {
 func (m Model) renderContactInfo(contact model.Contact) string {
 	var lines []string
 	
-	// Email
-	if contact.Email != "" {
-		lines = append(lines, m.renderField("Email", contact.Email))
+	// Emails (primary first)
+	for _, e := range contact.AllEmails() {
+		label := "Email"
+		if e.Label != "" {
+			label += " (" + e.Label + ")"
+		}
+		lines = append(lines, m.renderField(label, e.Value))
 	}
 	
-	// Phone
-	if contact.Phone != "" {
-		lines = append(lines, m.renderField("Phone", contact.Phone))
+	// Phones (primary first)
+	for _, p := range contact.AllPhones() {
+		label := "Phone"
+		if p.Label != "" {
+			label += " (" + p.Label + ")"
+		}
+		lines = append(lines, m.renderField(label, p.Value))
 	}
 	
 	// Company
@@ -199,12 +241,17 @@ func (m Model) renderContactInfo(contact model.Contact) string {
 	if contact.Website != "" {
 		lines = append(lines, m.renderField("Website", contact.Website))
 	}
+
+	// Avatar
+	if contact.Avatar != "" {
+		lines = append(lines, m.renderField("Avatar", contact.Avatar))
+	}
 	
 	// Tags
-	if len(contact.Tags) > 1 { // More than just "contact"
+	if len(contact.Tags) > 1 { // More than just the contact marker tag
 		var displayTags []string
 		for _, tag := range contact.Tags {
-			if tag != "contact" {
+			if tag != parser.ContactTag() {
 				displayTags = append(displayTags, tag)
 			}
 		}
@@ -272,6 +319,10 @@ func (m Model) renderContactHistory(contact model.Contact) string {
 			lastStr += " (yesterday)"
 		} else if days > 0 {
 			lastStr += fmt.Sprintf(" (%d days ago)", days)
+		} else if days == -1 {
+			lastStr += " (tomorrow, scheduled)"
+		} else {
+			lastStr += fmt.Sprintf(" (in %d days, scheduled)", -days)
 		}
 		lines = append(lines, m.renderField("Last Contacted", lastStr))
 		
@@ -286,8 +337,8 @@ func (m Model) renderContactHistory(contact model.Contact) string {
 	// Bump information
 	if contact.LastBumpDate != nil {
 		bumpStr := contact.LastBumpDate.Format("January 2, 2006")
-		if contact.BumpCount > 0 {
-			bumpStr += fmt.Sprintf(" (%d bumps)", contact.BumpCount)
+		if contact.BumpCount > 1 {
+			bumpStr = fmt.Sprintf("%d times, last on %s", contact.BumpCount, bumpStr)
 		}
 		lines = append(lines, m.renderField("Last Reviewed", bumpStr))
 	}
@@ -309,12 +360,9 @@ func (m Model) renderContactHistory(contact model.Contact) string {
 	return strings.Join(lines, "\n")
 }
 
-// renderContactContent renders the markdown content
-func (m Model) renderContactContent(contact model.Contact) string {
-	content := strings.TrimSpace(acore.StripLinksBlock(contact.Content))
-	if content == "" {
-		return emptyStyle.Render("No interaction history")
-	}
+// renderContactContent renders a block of markdown text, indented
+func (m Model) renderContactContent(content string) string {
+	content = strings.TrimSpace(content)
 	
 	// Simple rendering - just indent the content
 	lines := strings.Split(content, "\n")
@@ -362,6 +410,7 @@ func (m Model) renderRelatedEntities(contact model.Contact) string {
 // renderDetailFooter renders the footer with available actions
 func (m Model) renderDetailFooter() string {
 	keys := []string{
+		"j/k:scroll history",
 		"d:mark contacted",
 		"b:bump",
 		"e:edit",
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
 )
 
 // Edit form fields
@@ -192,11 +193,7 @@ func (m Model) handleStateSelection(msg tea.KeyMsg) Model {
 	// Show message about task creation
 	newState := m.editValues[fieldState]
 	if oldState != newState && newState != "" && newState != "ok" {
-		actionStates := map[string]bool{
-			"followup": true, "ping": true, "scheduled": true,
-			"timeout": true,
-		}
-		if actionStates[newState] {
+		if m.cfg.CreatesTaskFor(newState) {
 			m.message = fmt.Sprintf("Task will be created when saved (state → %s)", newState)
 		}
 	}
@@ -371,10 +368,10 @@ func (m *Model) initializeEditValues(contact model.Contact) {
 	m.editValues[fieldContactStyle] = string(contact.ContactStyle)
 	m.editValues[fieldState] = contact.State
 	
-	// Join tags (excluding "contact")
+	// Join tags (excluding the contact marker tag)
 	var tags []string
 	for _, tag := range contact.Tags {
-		if tag != "contact" {
+		if tag != parser.ContactTag() {
 			tags = append(tags, tag)
 		}
 	}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
+)
+
+// Below this terminal width the history pane goes under the contact fields
+// instead of beside them
+const sideBySideWidth = 100
+
+var (
+	historyDateStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("214"))
+	historyTypeStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("250"))
+	historyDetailStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245"))
+)
+
+// selectContact returns a command announcing that a contact was opened in
+// the detail view.
+func selectContact(contact model.Contact) tea.Cmd {
+	return func() tea.Msg {
+		return contactSelectedMsg{contact: contact}
+	}
+}
+
+// refreshHistory reloads the history pane from the selected contact's
+// interaction log, keeping the scroll position unless top is set.
+func (m *Model) refreshHistory(top bool) {
+	if m.selectedContact == nil {
+		return
+	}
+	m.layoutHistory()
+	m.history.SetContent(renderInteractionHistory(*m.selectedContact, m.history.Width))
+	if top {
+		m.history.GotoTop()
+	}
+}
+
+// layoutHistory sizes the history viewport for the terminal: half the width
+// beside the contact fields on wide terminals, otherwise the space left
+// under them.
+func (m *Model) layoutHistory() {
+	chrome := lipgloss.Height(m.renderDetailHeader(*m.selectedContact)) + 2 + // header and gap
+		2 + // pane title and its gap
+		2 // footer
+	if m.message != "" {
+		chrome += 2
+	}
+	if m.width >= sideBySideWidth {
+		m.history.Width = m.width/2 - 2
+		m.history.Height = m.height - chrome
+	} else {
+		m.history.Width = m.width - 2
+		m.history.Height = m.height - chrome - lipgloss.Height(m.renderDetailFields(*m.selectedContact))
+	}
+	m.history.Height = max(m.history.Height, 3)
+}
+
+// renderInteractionHistory renders the parsed interaction log, newest first,
+// wrapped to width.
+func renderInteractionHistory(contact model.Contact, width int) string {
+	interactions := parser.ParseInteractionLog(contact.Content)
+	if len(interactions) == 0 {
+		return emptyStyle.Render("No interactions logged")
+	}
+	sort.SliceStable(interactions, func(i, j int) bool {
+		return interactions[i].Date.After(interactions[j].Date)
+	})
+
+	width = max(width, 20)
+	wrap := lipgloss.NewStyle().Width(width)
+	var b strings.Builder
+	for i, in := range interactions {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		kind := string(in.Type)
+		if in.Minutes > 0 {
+			kind += fmt.Sprintf(", %d min", in.Minutes)
+		}
+		line := historyDateStyle.Render(in.Date.Format("2006-01-02")) + " " + historyTypeStyle.Render("("+kind+")")
+		if in.Summary != "" {
+			line += " " + valueStyle.Render(in.Summary)
+		}
+		b.WriteString(wrap.Render(line))
+		if in.Details != "" {
+			b.WriteString("\n")
+			b.WriteString(historyDetailStyle.PaddingLeft(2).Width(width).Render(in.Details))
+		}
+	}
+	return b.String()
+}
+
+// scrollHistory moves the history pane for a detail-view key, reporting
+// whether the key was a scroll key.
+func (m *Model) scrollHistory(key string) bool {
+	switch key {
+	case "j", "down":
+		m.history.LineDown(1)
+	case "k", "up":
+		m.history.LineUp(1)
+	case "ctrl+d", "pgdown":
+		m.history.HalfViewDown()
+	case "ctrl+u", "pgup":
+		m.history.HalfViewUp()
+	case "g", "home":
+		m.history.GotoTop()
+	case "G", "end":
+		m.history.GotoBottom()
+	default:
+		return false
+	}
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -78,7 +79,8 @@ func (m Model) updateInteractionType(msg tea.KeyMsg) (Model, tea.Cmd) {
 				if st.key == msg.String() {
 					m.interactionState = st.value
 					m.contactLogStep = 2 // Move to note entry
-					return m, nil
+					m.noteInput = newNoteInput()
+					return m, m.noteInput.Focus()
 				}
 			}
 		}
@@ -90,6 +92,7 @@ func (m Model) updateInteractionType(msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.contactLogStep = 1
 			m.interactionState = ""
 			m.interactionNote = ""
+			m.noteInput.Blur()
 			return m, nil
 
 		case "ctrl+c", "ctrl+q":
@@ -97,34 +100,42 @@ func (m Model) updateInteractionType(msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.resetContactLogging()
 			return m, nil
 
-		case "enter":
-			// Save without note
+		case "ctrl+s":
+			// Save, with whatever note has been typed
 			if m.contactToMark != nil {
+				m.interactionNote = strings.TrimSpace(m.noteInput.Value())
 				return m, m.logContactInteraction(*m.contactToMark)
 			}
 
-		case "ctrl+s":
-			// Save with note
-			if m.contactToMark != nil && m.interactionNote != "" {
+		case "enter":
+			// Enter on an empty note saves right away; otherwise it starts a new line
+			if m.contactToMark != nil && strings.TrimSpace(m.noteInput.Value()) == "" {
+				m.interactionNote = ""
 				return m, m.logContactInteraction(*m.contactToMark)
 			}
-
-		case "backspace":
-			if len(m.interactionNote) > 0 {
-				m.interactionNote = m.interactionNote[:len(m.interactionNote)-1]
-			}
-
-		default:
-			// Add to note
-			if len(msg.String()) == 1 && len(m.interactionNote) < 200 {
-				m.interactionNote += msg.String()
-			}
 		}
+
+		var cmd tea.Cmd
+		m.noteInput, cmd = m.noteInput.Update(msg)
+		return m, cmd
 	}
 
 	return m, nil
 }
 
+// newNoteInput returns an empty multi-line editor for an interaction note.
+// The first line becomes the log entry's summary; later lines are indented
+// beneath it.
+func newNoteInput() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "(optional)"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 2000
+	ta.SetWidth(56)
+	ta.SetHeight(5)
+	return ta
+}
+
 // resetContactLogging clears the contact logging state and returns to previous view
 func (m *Model) resetContactLogging() {
 	m.currentView = m.entryView  // Return to where we came from
@@ -132,6 +143,7 @@ func (m *Model) resetContactLogging() {
 	m.interactionType = ""
 	m.interactionState = ""
 	m.interactionNote = ""
+	m.noteInput.Blur()
 	m.contactLogStep = 0
 }
 
@@ -232,20 +244,13 @@ func (m Model) viewInteractionType() string {
 		boxStyle := lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(lipgloss.Color("238")).
-			Padding(1, 2).
-			Width(60)
-		
-		noteText := m.interactionNote
-		if noteText == "" {
-			noteText = lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Italic(true).Render("(optional)")
-		}
-		noteText += "█" // cursor
+			Padding(0, 1)
 		
-		b.WriteString(boxStyle.Render(noteText))
+		b.WriteString(boxStyle.Render(m.noteInput.View()))
 		b.WriteString("\n\n")
 
 		hotkeyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
-		b.WriteString(hotkeyStyle.Render("Enter to save • Ctrl+S to save with note • Esc to go back"))
+		b.WriteString(hotkeyStyle.Render("Ctrl+S to save • Enter for a new line (saves if empty) • Esc to go back • Ctrl+Q to cancel"))
 	}
 
 	// Pad to fill screen
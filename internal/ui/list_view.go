@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mph-llm-experiments/apeople/internal/model"
+	"github.com/mph-llm-experiments/apeople/internal/parser"
 )
 
 // Colors from denote-tasks
@@ -59,6 +60,7 @@ func (m Model) updateList(msg tea.KeyMsg) (Model, tea.Cmd) {
 		if m.cursor < len(m.filtered) {
 			m.selectedContact = &m.filtered[m.cursor]
 			m.currentView = ViewDetail
+			return m, selectContact(*m.selectedContact)
 		}
 		
 	case "/":
@@ -309,7 +311,9 @@ func (m Model) renderContactLine(contact model.Contact, selected bool) string {
 	// Days since contact
 	days := contact.DaysSinceContact()
 	daysStr := "   -"
-	if days >= 0 {
+	if contact.IsScheduled() {
+		daysStr = "schd"
+	} else if days >= 0 {
 		daysStr = fmt.Sprintf("%4d", days)
 	}
 	
@@ -346,7 +350,7 @@ func (m Model) renderContactLine(contact model.Contact, selected bool) string {
 	// Tags (remaining space)
 	var displayTags []string
 	for _, tag := range contact.Tags {
-		if tag != "contact" {
+		if tag != parser.ContactTag() {
 			displayTags = append(displayTags, tag)
 		}
 	}
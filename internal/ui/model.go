@@ -4,7 +4,10 @@ import (
 	"time"
 	
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mph-llm-experiments/apeople/internal/config"
 	"github.com/mph-llm-experiments/apeople/internal/model"
 )
 
@@ -24,9 +27,11 @@ const (
 // Model represents the application state
 type Model struct {
 	// Core state
+	cfg          *config.Config
 	contacts     []model.Contact
 	contactsDir  string
 	currentView  ViewMode
+	changes      <-chan struct{} // file change notifications, nil if not watching
 	
 	// List view state
 	list         list.Model
@@ -35,12 +40,14 @@ type Model struct {
 	
 	// Detail view state
 	selectedContact *model.Contact
+	history         viewport.Model // scrollable interaction log pane
 	
 	// Contact logging state
 	contactToMark      *model.Contact
 	interactionType    string
 	interactionState   string
 	interactionNote    string
+	noteInput          textarea.Model // multi-line editor for the note step
 	contactLogStep     int // 0=type, 1=state, 2=note
 	
 	// Edit view state
@@ -67,9 +74,11 @@ type Model struct {
 }
 
 // NewModel creates a new application model
-func NewModel(contactsDir string) Model {
+func NewModel(cfg *config.Config) Model {
 	return Model{
-		contactsDir:  contactsDir,
+		cfg:          cfg,
+		contactsDir:  cfg.ContactsDirectory,
+		changes:      watchContacts(cfg.ContactsDirectory),
 		currentView:  ViewList,
 		entryView:    ViewList, // Default to list view
 		selected:     make(map[string]bool),
@@ -88,6 +97,7 @@ func NewModel(contactsDir string) Model {
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.loadContacts(),
+		waitForChange(m.changes),
 	)
 }
 
@@ -104,6 +114,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.height = 24
 		}
 		m.ready = true
+		m.refreshHistory(false)
+		return m, nil
+		
+	case contactSelectedMsg:
+		m.refreshHistory(true)
 		return m, nil
 		
 	case tea.KeyMsg:
@@ -130,8 +145,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 	case contactsLoadedMsg:
 		m.contacts = msg.contacts
-		m.filtered = m.contacts
+		// Keep any active search/filters across reloads
+		m.applyFilters()
+		if m.selectedContact != nil {
+			for i := range m.contacts {
+				if m.contacts[i].FilePath == m.selectedContact.FilePath {
+					m.selectedContact = &m.contacts[i]
+					break
+				}
+			}
+			m.refreshHistory(false)
+		}
 		return m, nil
+
+	case contactsChangedMsg:
+		// Files changed on disk; reload and keep watching
+		return m, tea.Batch(m.loadContacts(), waitForChange(m.changes))
 		
 	case contactUpdatedMsg:
 		// Update the contact in our lists
@@ -145,6 +174,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update selected contact if it's the same one
 		if m.selectedContact != nil && m.selectedContact.FilePath == msg.contact.FilePath {
 			m.selectedContact = &msg.contact
+			m.refreshHistory(false)
 		}
 		
 		// Re-apply filters to update the filtered list
@@ -191,6 +221,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg
 		return m, nil
 	}
+
+	// Let the note editor see cursor blinks and other non-key messages
+	if m.currentView == ViewInteractionType && m.contactLogStep == 2 {
+		var cmd tea.Cmd
+		m.noteInput, cmd = m.noteInput.Update(msg)
+		return m, cmd
+	}
 	
 	return m, nil
 }
@@ -1,8 +1,6 @@
 package ui
 
 import (
-	"strings"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/mph-llm-experiments/apeople/internal/model"
 )
@@ -13,7 +11,7 @@ func (m Model) updateSearch(msg tea.KeyMsg) (Model, tea.Cmd) {
 	case tea.KeyEscape:
 		m.searchMode = false
 		m.searchQuery = ""
-		m.filtered = m.contacts
+		m.applyFilters()
 		m.cursor = 0
 		return m, nil
 		
@@ -38,47 +36,13 @@ func (m Model) updateSearch(msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, nil
 }
 
-// contactMatchesSearch checks if a contact matches the search query
-func (m *Model) contactMatchesSearch(contact model.Contact, query string) bool {
-	query = strings.ToLower(query)
-	
-	// Search in name
-	if strings.Contains(strings.ToLower(contact.Title), query) {
-		return true
-	}
-	// Search in company
-	if strings.Contains(strings.ToLower(contact.Company), query) {
-		return true
-	}
-	// Search in email
-	if strings.Contains(strings.ToLower(contact.Email), query) {
-		return true
-	}
-	// Search in tags
-	for _, tag := range contact.Tags {
-		if strings.Contains(strings.ToLower(tag), query) {
-			return true
-		}
-	}
-	// Search in label
-	if strings.Contains(strings.ToLower(contact.Label), query) {
-		return true
-	}
-	// Search in role
-	if strings.Contains(strings.ToLower(contact.Role), query) {
-		return true
-	}
-	
-	return false
-}
-
 // applyFilters applies search and filter criteria
 func (m *Model) applyFilters() {
 	m.filtered = []model.Contact{}
 	
 	for _, contact := range m.contacts {
-		// Apply search query
-		if m.searchQuery != "" && !m.contactMatchesSearch(contact, m.searchQuery) {
+		// Apply search query (same fields as `list --search`)
+		if m.searchQuery != "" && !model.MatchesSearch(&contact, m.searchQuery, false) {
 			continue
 		}
 		
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long the directory must be quiet before reloading, so a
+// burst of writes (a sync, an editor's save dance) triggers a single reload.
+const watchDebounce = 300 * time.Millisecond
+
+// contactsChangedMsg is sent when contact files change on disk.
+type contactsChangedMsg struct{}
+
+// watchContacts starts watching the contacts directory and returns a channel
+// that receives a value after each debounced burst of changes. Returns nil if
+// the directory can't be watched; the TUI then works without auto-reload.
+func watchContacts(dir string) <-chan struct{} {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer watcher.Close()
+		var timer *time.Timer
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !strings.HasSuffix(event.Name, ".md") || strings.HasPrefix(filepath.Base(event.Name), ".") {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(watchDebounce, func() {
+					select {
+					case changes <- struct{}{}:
+					default: // a reload is already pending
+					}
+				})
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return changes
+}
+
+// waitForChange returns a command that blocks until the watcher reports a change.
+func waitForChange(changes <-chan struct{}) tea.Cmd {
+	if changes == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		<-changes
+		return contactsChangedMsg{}
+	}
+}
@@ -11,12 +11,15 @@ import (
 var version = "0.2.0"
 
 func main() {
-	// Check for version flag early
-	for _, arg := range os.Args[1:] {
-		if arg == "--version" || arg == "-version" {
-			fmt.Printf("apeople v%s\n", version)
-			os.Exit(0)
+	cli.Version = version
+
+	// Answer --version before loading the config, which may be invalid
+	if handled, err := cli.RunVersion(os.Args[1:]); handled {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
+		return
 	}
 
 	// Load initial config (may be overridden by global flags)
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain lets the tests run the test binary as apeople itself: with
+// APEOPLE_TEST_MAIN set it runs main on its arguments instead of the tests.
+func TestMain(m *testing.M) {
+	if os.Getenv("APEOPLE_TEST_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// testHome is a sandbox for one apeople run: a contacts directory, a task
+// directory and a HOME holding the config that points at both.
+type testHome struct {
+	home     string
+	contacts string
+	tasks    string
+}
+
+// newTestHome sets up a sandbox. HOME and XDG_CONFIG_HOME point into it, so
+// neither the developer's apeople config nor their acore config (and with
+// it R2 sync and the atask directory) is seen.
+func newTestHome(t *testing.T) *testHome {
+	t.Helper()
+	h := &testHome{home: t.TempDir(), contacts: t.TempDir(), tasks: t.TempDir()}
+	configDir := filepath.Join(h.home, ".config", "apeople")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := fmt.Sprintf("contacts_directory = %q\ntask_directory = %q\n", h.contacts, h.tasks)
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+// runApeople runs apeople in the sandbox, returning its stdout, stderr and
+// exit code.
+func (h *testHome) runApeople(t *testing.T, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(),
+		"APEOPLE_TEST_MAIN=1",
+		"HOME="+h.home,
+		"XDG_CONFIG_HOME="+filepath.Join(h.home, ".config"),
+		"XDG_DATA_HOME="+filepath.Join(h.home, ".local", "share"),
+		"APEOPLE_DIR=",
+	)
+	var out, errOut bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &errOut
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatalf("run apeople: %v", err)
+	}
+	return out.String(), errOut.String(), code
+}
+
+// newQuietHome returns a sandbox whose contacts directory holds one
+// contact, #1.
+func newQuietHome(t *testing.T) *testHome {
+	t.Helper()
+	h := newTestHome(t)
+	if _, stderr, code := h.runApeople(t, "new", "Ada Lovelace"); code != 0 {
+		t.Fatalf("seed contact: exit %d: %s", code, stderr)
+	}
+	return h
+}
+
+func TestQuietSuccessPrintsNothing(t *testing.T) {
+	tests := [][]string{
+		{"new", "Grace Hopper", "--type", "work"},
+		{"log", "1", "--interaction", "call", "--note", "caught up"},
+		{"bump", "1"},
+		{"update", "1", "--state", "ping"},
+		{"delete", "1", "--confirm"},
+	}
+	for _, args := range tests {
+		t.Run(args[0], func(t *testing.T) {
+			stdout, stderr, code := newQuietHome(t).runApeople(t, append([]string{"--quiet"}, args...)...)
+			if code != 0 {
+				t.Fatalf("exit %d, want 0; stderr: %s", code, stderr)
+			}
+			if stdout != "" {
+				t.Errorf("stdout = %q, want nothing under --quiet", stdout)
+			}
+			if stderr != "" {
+				t.Errorf("stderr = %q, want nothing on success", stderr)
+			}
+		})
+	}
+}
+
+func TestQuietFailureReportsError(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"new", "Grace Hopper", "--type", "bogus"}, `invalid --type "bogus"`},
+		{[]string{"log", "99", "--interaction", "call"}, "contact not found: 99"},
+		{[]string{"bump", "99"}, "contact not found: 99"},
+		{[]string{"update", "99", "--state", "ping"}, "contact not found: 99"},
+		{[]string{"delete", "99", "--confirm"}, "contact not found: 99"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args[0], func(t *testing.T) {
+			stdout, stderr, code := newQuietHome(t).runApeople(t, append([]string{"--quiet"}, tt.args...)...)
+			if code == 0 {
+				t.Fatal("exit 0, want a failure")
+			}
+			if stdout != "" {
+				t.Errorf("stdout = %q, want nothing", stdout)
+			}
+			if !strings.HasPrefix(stderr, "Error: ") || !strings.Contains(stderr, tt.want) {
+				t.Errorf("stderr = %q, want an error mentioning %q", stderr, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietKeepsJSON(t *testing.T) {
+	stdout, stderr, code := newQuietHome(t).runApeople(t, "--quiet", "--json", "update", "1", "--state", "ping")
+	if code != 0 {
+		t.Fatalf("exit %d, want 0; stderr: %s", code, stderr)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(stdout), "{") {
+		t.Errorf("stdout = %q, want the JSON result even under --quiet", stdout)
+	}
+}
+
+func TestStateChangeTaskStaysInSandbox(t *testing.T) {
+	h := newQuietHome(t)
+	if _, stderr, code := h.runApeople(t, "--quiet", "update", "1", "--state", "ping"); code != 0 {
+		t.Fatalf("exit %d, want 0; stderr: %s", code, stderr)
+	}
+	entries, err := os.ReadDir(h.tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), "__task.md") {
+		t.Errorf("task directory holds %v, want the one ping task", entries)
+	}
+}